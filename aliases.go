@@ -0,0 +1,25 @@
+package tz
+
+import "github.com/catmullet/tz/internal/zoneinfo"
+
+// WithCanonicalNames makes TimeZone return canonical IANA names, resolving aliases such as
+// Asia/Calcutta to Asia/Kolkata with the embedded tzdb backward links. Feature and the functions
+// listing zones, such as Zones, ZonesInBounds, ZonesInGeometry, ZonesAt and EquivalentZones, use the
+// canonical names too, merging zones that the data holds under several aliases.
+func WithCanonicalNames() Option {
+	return func(fc *Collection) {
+		fc.canonicalNames = true
+	}
+}
+
+// CanonicalTimeZone returns the canonical IANA name of a time zone, such as Asia/Kolkata for Asia/Calcutta,
+// and tzid itself when it is not a known alias.
+func CanonicalTimeZone(tzid string) string {
+	return zoneinfo.Canonical(tzid)
+}
+
+// AreAliases reports whether two time zone names refer to the same zone, because they are equal, one is an
+// alias of the other, or both are aliases of a third.
+func AreAliases(a, b string) bool {
+	return a != "" && CanonicalTimeZone(a) == CanonicalTimeZone(b)
+}
@@ -0,0 +1,132 @@
+package tz
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCanonicalTimeZone(t *testing.T) {
+	var tests = map[string]string{
+		"Asia/Calcutta":                "Asia/Kolkata",
+		"America/Indianapolis":         "America/Indiana/Indianapolis",
+		"America/Fort_Wayne":           "America/Indiana/Indianapolis",
+		"US/Eastern":                   "America/New_York",
+		"Europe/Kiev":                  "Europe/Kyiv",
+		"UTC":                          "Etc/UTC",
+		"Asia/Kolkata":                 "Asia/Kolkata",
+		"America/Indiana/Indianapolis": "America/Indiana/Indianapolis",
+		"Mars/Olympus_Mons":            "Mars/Olympus_Mons",
+	}
+	for tzid, want := range tests {
+		if got := CanonicalTimeZone(tzid); got != want {
+			t.Errorf("%s: got %q, expected %q", tzid, got, want)
+		}
+	}
+}
+
+func TestAreAliases(t *testing.T) {
+	var tests = []struct {
+		A, B    string
+		Aliases bool
+	}{
+		{A: "Asia/Calcutta", B: "Asia/Kolkata", Aliases: true},
+		{A: "Asia/Kolkata", B: "Asia/Calcutta", Aliases: true},
+		{A: "America/Indianapolis", B: "America/Fort_Wayne", Aliases: true},
+		{A: "Europe/Berlin", B: "Europe/Berlin", Aliases: true},
+		{A: "America/Indianapolis", B: "America/New_York", Aliases: false},
+		{A: "Europe/Oslo", B: "Europe/Berlin", Aliases: false},
+		{A: "", B: "", Aliases: false},
+	}
+	for _, test := range tests {
+		if got := AreAliases(test.A, test.B); got != test.Aliases {
+			t.Errorf("%s %s: got %v, expected %v", test.A, test.B, got, test.Aliases)
+		}
+	}
+}
+
+func TestWithCanonicalNames(t *testing.T) {
+	const geojson = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Asia/Calcutta"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[70, 10], [90, 10], [90, 30], [70, 30], [70, 10]]]}}
+]}`
+	var fc = newTestCollection(t, geojson)
+	if tz := fc.TimeZone(20, 80); tz != "Asia/Calcutta" {
+		t.Errorf("got %q, expected the name in the data", tz)
+	}
+	WithCanonicalNames()(fc)
+	if tz := fc.TimeZone(20, 80); tz != "Asia/Kolkata" {
+		t.Errorf("got %q, expected Asia/Kolkata", tz)
+	}
+	if tz := fc.TimeZone(40, 0); tz != "" {
+		t.Errorf("got %q outside every zone", tz)
+	}
+	if windowsID, err := WindowsZone("Asia/Calcutta"); err != nil || windowsID != "India Standard Time" {
+		t.Errorf("windows: got %q %v", windowsID, err)
+	}
+}
+
+func TestCanonicalNamesAcrossCollection(t *testing.T) {
+	// The data holds India under both names and Ukraine under its old one.
+	var fc = newTestCollection(t, `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Asia/Calcutta"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[70, 10], [80, 10], [80, 30], [70, 30], [70, 10]]]}},
+	{"type": "Feature", "properties": {"tzid": "Asia/Kolkata"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[80, 10], [90, 10], [90, 30], [80, 30], [80, 10]]]}},
+	{"type": "Feature", "properties": {"tzid": "Europe/Kiev"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[22, 44], [40, 44], [40, 52], [22, 52], [22, 44]]]}}
+]}`)
+	WithCanonicalNames()(fc)
+
+	for _, p := range []Point{{Lon: 75, Lat: 20}, {Lon: 85, Lat: 20}, {Lon: 30, Lat: 50}} {
+		tzid := fc.TimeZone(p.Lat, p.Lon)
+		f, err := fc.Feature(tzid)
+		if err != nil {
+			t.Errorf("%v: Feature(%q): %v", p, tzid, err)
+			continue
+		}
+		if !AreAliases(f.TimeZone(), tzid) {
+			t.Errorf("%v: Feature(%q) returned %q", p, tzid, f.TimeZone())
+		}
+	}
+	if f, err := fc.Feature("Europe/Kiev"); err != nil || f.TimeZone() != "Europe/Kiev" {
+		t.Errorf("Feature by alias: got %v, %v", f, err)
+	}
+
+	var zones []string
+	for _, z := range fc.Zones() {
+		zones = append(zones, fmt.Sprintf("%s %d", z.TZID, z.Features))
+	}
+	if got := strings.Join(zones, ", "); got != "Asia/Kolkata 2, Europe/Kyiv 1" {
+		t.Errorf("Zones: got %s", got)
+	}
+	if got := fc.ZonesInBounds(Point{Lon: 0, Lat: 0}, Point{Lon: 100, Lat: 60}); strings.Join(got, ",") != "Asia/Kolkata,Europe/Kyiv" {
+		t.Errorf("ZonesInBounds: got %v", got)
+	}
+
+	shares, err := fc.ZonesInGeometry(Geometry{Coordinates: []Coordinates{{
+		Polygon:  []Point{{Lon: 75, Lat: 15}, {Lon: 85, Lat: 15}, {Lon: 85, Lat: 25}, {Lon: 75, Lat: 25}, {Lon: 75, Lat: 15}},
+		MinPoint: Point{Lon: 75, Lat: 15}, MaxPoint: Point{Lon: 85, Lat: 25},
+	}}, MinPoint: Point{Lon: 75, Lat: 15}, MaxPoint: Point{Lon: 85, Lat: 25}})
+	if err != nil || len(shares) != 1 || shares[0].TZID != "Asia/Kolkata" || math.Abs(shares[0].Share-1) > 1e-9 {
+		t.Errorf("ZonesInGeometry: got %+v, %v", shares, err)
+	}
+
+	matches, err := fc.ZonesAt(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), func(time.Time) bool { return true })
+	if err != nil || len(matches) != 2 || matches[0].TZID != "Asia/Kolkata" || len(matches[0].Features) != 2 ||
+		matches[1].TZID != "Europe/Kyiv" {
+		t.Errorf("ZonesAt: got %+v, %v", matches, err)
+	}
+
+	classes, err := fc.EquivalentZones(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil || len(classes) != 2 {
+		t.Fatalf("EquivalentZones: got %+v, %v", classes, err)
+	}
+	for _, class := range classes {
+		if len(class.Members) != 1 || class.Members[0] != CanonicalTimeZone(class.Members[0]) {
+			t.Errorf("EquivalentZones: got %+v", class)
+		}
+	}
+}
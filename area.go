@@ -0,0 +1,58 @@
+package tz
+
+import "math"
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371008.8
+
+// Area returns the area of the polygon less its holes on the sphere in square meters.
+// Edges are taken as straight lines in longitude and latitude, the same as the containment test.
+func (c Coordinates) Area() float64 {
+	var area = math.Abs(ringArea(c.Polygon))
+	for _, hole := range c.Holes {
+		area -= math.Abs(ringArea(hole))
+	}
+	return area
+}
+
+// Area returns the total area of the geometry's polygons on the sphere in square meters.
+func (g Geometry) Area() float64 {
+	var area float64
+	for _, c := range g.Coordinates {
+		area += c.Area()
+	}
+	return area
+}
+
+// ringArea returns the signed area of a ring, positive when it winds counterclockwise.
+func ringArea(ring []Point) float64 {
+	var area float64
+	for i := 1; i < len(ring); i++ {
+		area += edgeArea(ring[i-1], ring[i])
+	}
+	if n := len(ring); n > 2 && ring[0] != ring[n-1] {
+		area += edgeArea(ring[n-1], ring[0])
+	}
+	return area
+}
+
+// edgeArea returns the contribution of the edge a→b to the signed area of the ring it is part of.
+// By Green's theorem the area of a region on the sphere is -R²∮sin(φ)dλ around its boundary, which
+// integrates exactly along an edge where latitude varies linearly with longitude.
+func edgeArea(a, b Point) float64 {
+	var (
+		lambda = (b.Lon - a.Lon) * math.Pi / 180
+		phiA   = a.Lat * math.Pi / 180
+		phiB   = b.Lat * math.Pi / 180
+		sinInt float64
+	)
+	if lambda == 0 {
+		return 0
+	}
+	if d := phiB - phiA; math.Abs(d) < 1e-12 {
+		sinInt = math.Sin(phiA)
+	} else {
+		sinInt = (math.Cos(phiA) - math.Cos(phiB)) / d
+	}
+	return -earthRadius * earthRadius * lambda * sinInt
+}
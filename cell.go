@@ -0,0 +1,69 @@
+package tz
+
+// cellUniformTolerance is the uncovered share of a cell still counted as inside a single zone.
+const cellUniformTolerance = 1e-9
+
+// CellZone is the time zone of a coordinate cell such as a geohash or a plus code.
+type CellZone struct {
+	// TZID is the time zone at the center of the cell.
+	TZID string
+	// Center, MinPoint and MaxPoint are the decoded cell.
+	Center   Point
+	MinPoint Point
+	MaxPoint Point
+	// Zones lists every time zone covering part of the cell, largest share first.
+	Zones []ZoneShare
+	// Uniform reports whether the whole cell lies in TZID.
+	Uniform bool
+}
+
+// TimeZoneGeohash returns the time zone of a geohash cell.
+func (fc Collection) TimeZoneGeohash(hash string) (CellZone, error) {
+	minPoint, maxPoint, err := DecodeGeohash(hash)
+	if err != nil {
+		return CellZone{}, err
+	}
+	return fc.cellZone(minPoint, maxPoint)
+}
+
+// TimeZonePlusCode returns the time zone of a full Open Location Code cell.
+func (fc Collection) TimeZonePlusCode(code string) (CellZone, error) {
+	minPoint, maxPoint, err := DecodePlusCode(code)
+	if err != nil {
+		return CellZone{}, err
+	}
+	return fc.cellZone(minPoint, maxPoint)
+}
+
+func (fc Collection) cellZone(minPoint, maxPoint Point) (CellZone, error) {
+	var cell = CellZone{
+		Center:   Point{Lon: (minPoint.Lon + maxPoint.Lon) / 2, Lat: (minPoint.Lat + maxPoint.Lat) / 2},
+		MinPoint: minPoint,
+		MaxPoint: maxPoint,
+	}
+	// Zones and Uniform come from the exact overlay, so the center is looked up exactly as well
+	// rather than with a sampled polygon that can disagree near a border.
+	fc.accuracy = AccuracyExact
+	var err error
+	if cell.TZID, err = fc.timeZone(cell.Center.Lat, cell.Center.Lon); err != nil {
+		return cell, err
+	}
+
+	var g = Geometry{
+		Coordinates: []Coordinates{{
+			Polygon: []Point{minPoint, {Lon: maxPoint.Lon, Lat: minPoint.Lat}, maxPoint,
+				{Lon: minPoint.Lon, Lat: maxPoint.Lat}, minPoint},
+			MinPoint: minPoint,
+			MaxPoint: maxPoint,
+		}},
+		MinPoint: minPoint,
+		MaxPoint: maxPoint,
+	}
+	zones, err := fc.ZonesInGeometry(g)
+	if err != nil {
+		return cell, err
+	}
+	cell.Zones = zones
+	cell.Uniform = len(zones) == 1 && zones[0].TZID == cell.TZID && 1-zones[0].Share < cellUniformTolerance
+	return cell, nil
+}
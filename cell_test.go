@@ -0,0 +1,108 @@
+package tz
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTimeZoneGeohash(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var tests = []struct {
+		Hash    string
+		TZID    string
+		Zones   int
+		Uniform bool
+	}{
+		{Hash: "9x4n", TZID: "America/Denver", Zones: 1, Uniform: true},
+		// Centered in the western arm of the U but reaching past its western edge at 110W.
+		{Hash: "9x1y", TZID: "America/Denver", Zones: 1, Uniform: false},
+		{Hash: "9X4N", TZID: "America/Denver", Zones: 1, Uniform: true},
+	}
+	for _, test := range tests {
+		cell, err := fc.TimeZoneGeohash(test.Hash)
+		if err != nil {
+			t.Errorf("%s: %v", test.Hash, err)
+			continue
+		}
+		if cell.TZID != test.TZID || len(cell.Zones) != test.Zones || cell.Uniform != test.Uniform {
+			t.Errorf("%s: got %+v, expected %s, %d zones, uniform %v", test.Hash, cell, test.TZID, test.Zones, test.Uniform)
+		}
+	}
+	for _, hash := range []string{"u4pru", "9x4a"} {
+		if _, err := fc.TimeZoneGeohash(hash); err == nil {
+			t.Errorf("%s: expected error", hash)
+		}
+	}
+}
+
+func TestTimeZonePlusCode(t *testing.T) {
+	var fc = newTestCollection(t, `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Europe/Zurich"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[8, 47], [8.52495, 47], [8.52495, 48], [8, 48], [8, 47]]]}},
+	{"type": "Feature", "properties": {"tzid": "Europe/Vaduz"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[8.52495, 47], [9, 47], [9, 48], [8.52495, 48], [8.52495, 47]]]}}
+]}`)
+	var tests = []struct {
+		Code    string
+		TZID    string
+		Zones   []string
+		Uniform bool
+	}{
+		{Code: "8FVC9G8F+6X", TZID: "Europe/Zurich", Zones: []string{"Europe/Zurich", "Europe/Vaduz"}},
+		{Code: "8FVC9G8F+6XQ", TZID: "Europe/Vaduz", Zones: []string{"Europe/Vaduz"}, Uniform: true},
+		{Code: "8FVC9F00+", TZID: "Europe/Zurich", Zones: []string{"Europe/Zurich"}, Uniform: true},
+	}
+	for _, test := range tests {
+		cell, err := fc.TimeZonePlusCode(test.Code)
+		if err != nil {
+			t.Errorf("%s: %v", test.Code, err)
+			continue
+		}
+		var zones []string
+		for _, z := range cell.Zones {
+			zones = append(zones, z.TZID)
+		}
+		if cell.TZID != test.TZID || cell.Uniform != test.Uniform || len(zones) != len(test.Zones) {
+			t.Errorf("%s: got %+v, expected %s %v uniform %v", test.Code, cell, test.TZID, test.Zones, test.Uniform)
+			continue
+		}
+		for i := range zones {
+			if zones[i] != test.Zones[i] {
+				t.Errorf("%s: got zones %v, expected %v", test.Code, zones, test.Zones)
+			}
+		}
+	}
+	if _, err := fc.TimeZonePlusCode("9G8F+6X"); err == nil {
+		t.Error("expected error for short code")
+	}
+}
+
+func TestCellZoneExactCenter(t *testing.T) {
+	// America/Denver is a square with a narrow notch in its top edge, filled by America/Phoenix. The
+	// bottom edge has enough vertices that the fast lookup samples every fourth one, and the notch
+	// falls between two samples.
+	var ring strings.Builder
+	ring.WriteString("[0, 0]")
+	for i := 1; i <= 4001; i++ {
+		fmt.Fprintf(&ring, ", [%v, 0]", 10*float64(i)/4002)
+	}
+	ring.WriteString(", [10, 0], [10, 10], [6, 10], [5.2, 10], [5, 5], [4.8, 10], [4, 10], [0, 10], [0, 0]")
+	var fc = newTestCollection(t, `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "America/Denver"}, "geometry": {"type": "Polygon",
+		"coordinates": [[`+ring.String()+`]]}},
+	{"type": "Feature", "properties": {"tzid": "America/Phoenix"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[5.2, 10], [5, 5], [4.8, 10], [5.2, 10]]]}}
+]}`)
+	if tzid := fc.TimeZone(8, 5); tzid != "America/Denver" {
+		t.Fatalf("fast lookup in the notch: got %q, expected the sampled polygon to miss it", tzid)
+	}
+
+	cell, err := fc.cellZone(Point{Lon: 4.99, Lat: 7.99}, Point{Lon: 5.01, Lat: 8.01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cell.TZID != "America/Phoenix" || len(cell.Zones) != 1 || cell.Zones[0].TZID != "America/Phoenix" || !cell.Uniform {
+		t.Errorf("got %+v, expected a uniform America/Phoenix cell", cell)
+	}
+}
@@ -0,0 +1,155 @@
+// Command tz prints the time zone at coordinates given as arguments or read from stdin.
+//
+//	tz [flags] lat lon
+//	tz [flags] -- -33.9249 18.4241
+//	tz [flags] < coordinates.txt
+//
+// Flags come before the coordinates, and a negative latitude needs -- in front of it so it is not read
+// as a flag. Each line of stdin holds one coordinate as "lat lon" or "lat,lon".
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/catmullet/tz"
+	"github.com/catmullet/tz/internal/lookupflags"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// result is one lookup as written by the json and ndjson formats.
+// Lat and Lon are left out for input that did not parse, which Input holds instead.
+type result struct {
+	Lat   *float64 `json:"lat,omitempty"`
+	Lon   *float64 `json:"lon,omitempty"`
+	Input string   `json:"input,omitempty"`
+	TZID  string   `json:"tzid"`
+	Error string   `json:"error,omitempty"`
+}
+
+var newLookup = func(opts ...tz.Option) (tz.TimeZoneLookup, error) {
+	return tz.NewTZ(opts...)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		flags       = flag.NewFlagSet("tz", flag.ContinueOnError)
+		lookupFlags = lookupflags.Register(flags)
+		format      = flags.String("format", "plain", "output format, plain, json or ndjson")
+	)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tz [flags] [--] [lat lon]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	var coords = flags.Args()
+
+	opts, err := lookupFlags.Options()
+	if err != nil {
+		fmt.Fprintln(stderr, "tz:", err)
+		return 2
+	}
+	if *format != "plain" && *format != "json" && *format != "ndjson" {
+		fmt.Fprintf(stderr, "tz: unknown format %q\n", *format)
+		return 2
+	}
+
+	var input io.Reader = stdin
+	switch len(coords) {
+	case 0:
+	case 2:
+		input = strings.NewReader(coords[0] + " " + coords[1])
+	default:
+		flags.Usage()
+		return 2
+	}
+
+	lookup, err := newLookup(opts...)
+	if err != nil {
+		fmt.Fprintln(stderr, "tz:", err)
+		return 1
+	}
+
+	var (
+		out     = bufio.NewWriter(stdout)
+		results []result
+		status  int
+	)
+	defer out.Flush()
+
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		r := lookupLine(lookup, line)
+		if r.Error != "" {
+			fmt.Fprintf(stderr, "tz: %s\n", r.Error)
+			status = 1
+		}
+		switch *format {
+		case "plain":
+			if r.Error == "" {
+				fmt.Fprintln(out, r.TZID)
+			}
+		case "ndjson":
+			b, _ := json.Marshal(r)
+			fmt.Fprintf(out, "%s\n", b)
+		case "json":
+			results = append(results, r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "tz:", err)
+		return 1
+	}
+
+	if *format == "json" {
+		if results == nil {
+			results = []result{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			fmt.Fprintln(stderr, "tz:", err)
+			return 1
+		}
+	}
+	return status
+}
+
+// lookupLine parses "lat lon" or "lat,lon" and looks up its time zone.
+func lookupLine(lookup tz.TimeZoneLookup, line string) result {
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) != 2 {
+		return result{Input: line, Error: fmt.Sprintf("expected lat and lon, got %q", line)}
+	}
+	lat, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return result{Input: line, Error: fmt.Sprintf("invalid latitude %q", fields[0])}
+	}
+	lon, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return result{Input: line, Error: fmt.Sprintf("invalid longitude %q", fields[1])}
+	}
+
+	r := result{Lat: &lat, Lon: &lon, TZID: lookup.TimeZone(lat, lon)}
+	if r.TZID == "" {
+		r.Error = fmt.Sprintf("no time zone found at %v, %v", lat, lon)
+	}
+	return r
+}
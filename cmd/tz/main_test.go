@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/catmullet/tz"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeLookup map[[2]float64]string
+
+func (f fakeLookup) TimeZone(lat, lon float64) string {
+	return f[[2]float64{lat, lon}]
+}
+
+func (f fakeLookup) Location(lat, lon float64) (*time.Location, error) {
+	return time.LoadLocation(f.TimeZone(lat, lon))
+}
+
+func init() {
+	newLookup = func(opts ...tz.Option) (tz.TimeZoneLookup, error) {
+		return fakeLookup{
+			{41.8976, -87.6205}: "America/Chicago",
+			{-33.9249, 18.4241}: "Africa/Johannesburg",
+		}, nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	var tests = []struct {
+		Name   string
+		Args   []string
+		Stdin  string
+		Stdout string
+		Status int
+	}{
+		{Name: "arguments", Args: []string{"41.8976", "-87.6205"}, Stdout: "America/Chicago\n"},
+		{Name: "negative latitude after --", Args: []string{"-format", "ndjson", "--", "-33.9249", "18.4241"},
+			Stdout: `{"lat":-33.9249,"lon":18.4241,"tzid":"Africa/Johannesburg"}` + "\n"},
+		{Name: "negative latitude without --", Args: []string{"-33.9249", "18.4241"}, Status: 2},
+		{Name: "numeric flag value", Args: []string{"-format", "ndjson", "-data", "7", "41.8976", "-87.6205"},
+			Stdout: `{"lat":41.8976,"lon":-87.6205,"tzid":"America/Chicago"}` + "\n"},
+		{Name: "stdin", Stdin: "41.8976 -87.6205\n# comment\n\n-33.9249,18.4241\n",
+			Stdout: "America/Chicago\nAfrica/Johannesburg\n"},
+		{Name: "stdin with bad rows", Args: []string{"-format", "ndjson"}, Stdin: "41.8976 -87.6205\nnorth 3\n0 0\n",
+			Stdout: `{"lat":41.8976,"lon":-87.6205,"tzid":"America/Chicago"}` + "\n" +
+				`{"input":"north 3","tzid":"","error":"invalid latitude \"north\""}` + "\n" +
+				`{"lat":0,"lon":0,"tzid":"","error":"no time zone found at 0, 0"}` + "\n",
+			Status: 1},
+		{Name: "one argument", Args: []string{"41.8976"}, Status: 2},
+		{Name: "unknown format", Args: []string{"-format", "xml", "1", "2"}, Status: 2},
+		{Name: "unknown accuracy", Args: []string{"-accuracy", "perfect", "1", "2"}, Status: 2},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		status := run(tt.Args, strings.NewReader(tt.Stdin), &stdout, &stderr)
+		if status != tt.Status {
+			t.Errorf("%s: status %d, expected %d: %s", tt.Name, status, tt.Status, stderr.String())
+		}
+		if stdout.String() != tt.Stdout {
+			t.Errorf("%s: got %q, expected %q", tt.Name, stdout.String(), tt.Stdout)
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"-format", "json", "-accuracy", "exact"}, strings.NewReader("41.8976 -87.6205\n-33.9249 18.4241\n"),
+		&stdout, &stderr)
+	if status != 0 {
+		t.Fatalf("status %d: %s", status, stderr.String())
+	}
+	var results []result
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].TZID != "America/Chicago" || results[1].TZID != "Africa/Johannesburg" {
+		t.Errorf("got %+v", results)
+	}
+}
@@ -0,0 +1,467 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/catmullet/tz"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// batchSize is the number of records handed to a worker at a time.
+const batchSize = 512
+
+// record is one input row on its way through the pipeline.
+type record struct {
+	line     int
+	fields   []string
+	raw      []byte
+	members  []member
+	lat, lon float64
+	tzid     string
+	offset   string
+	err      error
+}
+
+// format reads records from the input and writes enriched or rejected records in its own encoding.
+type format interface {
+	// read returns the next record, with err set when it could not be parsed, or io.EOF at the end.
+	read() (*record, error)
+	write(r *record) error
+	reject(r *record) error
+	flush() error
+}
+
+type enricher struct {
+	lookup  tz.TimeZoneLookup
+	workers int
+	// offsetAt, when set, adds the UTC offset of each record's zone at that instant.
+	offsetAt *time.Time
+}
+
+type batch struct {
+	seq     int
+	records []*record
+}
+
+// stats counts the records written and rejected by a run.
+type stats struct {
+	written  int
+	rejected int
+}
+
+// run streams records from f through the worker pool and writes them back in input order.
+// At most workers*2 batches are read ahead of the writer, so a slow batch holding up the output
+// cannot make the batches finished behind it pile up in memory.
+func (e *enricher) run(f format) (stats, error) {
+	var (
+		in       = make(chan batch, e.workers)
+		out      = make(chan batch, e.workers)
+		inFlight = make(chan struct{}, e.workers*2)
+		readErr  error
+		wg       sync.WaitGroup
+	)
+
+	go func() {
+		defer close(in)
+		var b = batch{records: make([]*record, 0, batchSize)}
+		for {
+			r, err := f.read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				readErr = err
+				break
+			}
+			b.records = append(b.records, r)
+			if len(b.records) == batchSize {
+				inFlight <- struct{}{}
+				in <- b
+				b = batch{seq: b.seq + 1, records: make([]*record, 0, batchSize)}
+			}
+		}
+		if len(b.records) > 0 {
+			inFlight <- struct{}{}
+			in <- b
+		}
+	}()
+
+	wg.Add(e.workers)
+	for i := 0; i < e.workers; i++ {
+		go func() {
+			defer wg.Done()
+			for b := range in {
+				for _, r := range b.records {
+					e.enrich(r)
+				}
+				out <- b
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	var (
+		s       stats
+		next    int
+		pending = make(map[int]batch)
+		err     error
+	)
+	for b := range out {
+		pending[b.seq] = b
+		for {
+			b, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-inFlight
+			if err != nil {
+				continue
+			}
+			for _, r := range b.records {
+				if r.err != nil {
+					s.rejected++
+					err = f.reject(r)
+				} else {
+					s.written++
+					err = f.write(r)
+				}
+				if err != nil {
+					break
+				}
+			}
+		}
+	}
+	if err == nil {
+		err = readErr
+	}
+	if flushErr := f.flush(); err == nil {
+		err = flushErr
+	}
+	return s, err
+}
+
+func (e *enricher) enrich(r *record) {
+	if r.err != nil {
+		return
+	}
+	if r.tzid = e.lookup.TimeZone(r.lat, r.lon); r.tzid == "" {
+		r.err = fmt.Errorf("no time zone found at %v, %v", r.lat, r.lon)
+		return
+	}
+	if e.offsetAt == nil {
+		return
+	}
+	loc, err := tz.LoadLocation(r.tzid)
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.offset = e.offsetAt.In(loc).Format("-07:00")
+}
+
+func parseCoordinates(lat, lon string) (float64, float64, error) {
+	latf, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude %q", lat)
+	}
+	lonf, err := strconv.ParseFloat(lon, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude %q", lon)
+	}
+	return latf, lonf, nil
+}
+
+// csvFormat reads and writes CSV, appending the tzid and optional offset columns to every row.
+// Rejected rows keep their fields and gain the reason as a final column.
+type csvFormat struct {
+	r          *csv.Reader
+	w          *csv.Writer
+	rejects    *csv.Writer
+	latCol     int
+	lonCol     int
+	line       int
+	withOffset bool
+}
+
+// newCSVFormat resolves the lat and lon columns, by header name or zero based index, and writes the headers.
+func newCSVFormat(in io.Reader, out, rejects io.Writer, header bool, latCol, lonCol string, names columnNames) (*csvFormat, error) {
+	var f = &csvFormat{r: csv.NewReader(in), w: csv.NewWriter(out), rejects: csv.NewWriter(rejects), withOffset: names.offset != ""}
+	f.r.FieldsPerRecord = -1
+	f.r.ReuseRecord = false
+
+	var headers []string
+	if header {
+		var err error
+		if headers, err = f.r.Read(); err != nil {
+			if err == io.EOF {
+				err = errors.New("missing header row")
+			}
+			return nil, err
+		}
+		f.line = 1
+	}
+
+	var err error
+	if f.latCol, err = columnIndex(headers, latCol); err != nil {
+		return nil, err
+	}
+	if f.lonCol, err = columnIndex(headers, lonCol); err != nil {
+		return nil, err
+	}
+
+	if header {
+		out := append(append([]string{}, headers...), names.tzid)
+		if f.withOffset {
+			out = append(out, names.offset)
+		}
+		if err := f.w.Write(out); err != nil {
+			return nil, err
+		}
+		if err := f.rejects.Write(append(append([]string{}, headers...), "error")); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// columnIndex finds a column by header name, falling back to a zero based index.
+func columnIndex(headers []string, col string) (int, error) {
+	for i, h := range headers {
+		if h == col {
+			return i, nil
+		}
+	}
+	if i, err := strconv.Atoi(col); err == nil && i >= 0 {
+		return i, nil
+	}
+	return 0, fmt.Errorf("column %q not found", col)
+}
+
+func (f *csvFormat) read() (*record, error) {
+	fields, err := f.r.Read()
+	f.line++
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &record{line: f.line, fields: fields, err: err}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var r = &record{line: f.line, fields: fields}
+	if f.latCol >= len(fields) || f.lonCol >= len(fields) {
+		r.err = fmt.Errorf("row %d has %d columns", f.line, len(fields))
+		return r, nil
+	}
+	r.lat, r.lon, r.err = parseCoordinates(fields[f.latCol], fields[f.lonCol])
+	return r, nil
+}
+
+func (f *csvFormat) write(r *record) error {
+	out := append(r.fields, r.tzid)
+	if f.withOffset {
+		out = append(out, r.offset)
+	}
+	return f.w.Write(out)
+}
+
+func (f *csvFormat) reject(r *record) error {
+	return f.rejects.Write(append(r.fields, r.err.Error()))
+}
+
+func (f *csvFormat) flush() error {
+	f.w.Flush()
+	f.rejects.Flush()
+	if err := f.w.Error(); err != nil {
+		return err
+	}
+	return f.rejects.Error()
+}
+
+// ndjsonFormat reads and writes one JSON object per line, setting the tzid and optional offset keys of
+// each object without disturbing the rest of it. Keys the object already has are overwritten in place
+// and missing ones are added at the end. Rejected lines are written as objects holding the line
+// number, the reason and the original line.
+type ndjsonFormat struct {
+	s       *bufio.Scanner
+	w       *bufio.Writer
+	rejects *bufio.Writer
+	latKey  string
+	lonKey  string
+	names   columnNames
+	line    int
+}
+
+func newNDJSONFormat(in io.Reader, out, rejects io.Writer, latKey, lonKey string, names columnNames) *ndjsonFormat {
+	var f = &ndjsonFormat{s: bufio.NewScanner(in), w: bufio.NewWriter(out), rejects: bufio.NewWriter(rejects),
+		latKey: latKey, lonKey: lonKey, names: names}
+	f.s.Buffer(make([]byte, 64*1024), 64<<20)
+	return f
+}
+
+func (f *ndjsonFormat) read() (*record, error) {
+	for f.s.Scan() {
+		f.line++
+		raw := bytes.TrimSpace(f.s.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var r = &record{line: f.line, raw: append([]byte{}, raw...)}
+		var err error
+		if r.members, err = objectMembers(r.raw); err != nil {
+			r.err = fmt.Errorf("invalid JSON object: %v", err)
+			return r, nil
+		}
+		r.lat, r.lon, r.err = parseCoordinates(jsonNumber(r.value(f.latKey)), jsonNumber(r.value(f.lonKey)))
+		return r, nil
+	}
+	if err := f.s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// member is a key of an NDJSON object and the position of its value in the line.
+type member struct {
+	key        string
+	start, end int
+}
+
+// objectMembers lists the members of a JSON object in the order they appear.
+func objectMembers(raw []byte) ([]member, error) {
+	var d = json.NewDecoder(bytes.NewReader(raw))
+	if t, err := d.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('{') {
+		return nil, errors.New("not an object")
+	}
+	var members []member
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return nil, err
+		}
+		end := int(d.InputOffset())
+		members = append(members, member{key: t.(string), start: end - len(value), end: end})
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after object")
+	}
+	return members, nil
+}
+
+// value returns the raw value of key, the last one when the object repeats it.
+func (r *record) value(key string) json.RawMessage {
+	for i := len(r.members) - 1; i >= 0; i-- {
+		if m := r.members[i]; m.key == key {
+			return json.RawMessage(r.raw[m.start:m.end])
+		}
+	}
+	return nil
+}
+
+// jsonNumber returns a JSON number, or the contents of a JSON string holding one.
+func jsonNumber(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+func (f *ndjsonFormat) write(r *record) error {
+	var (
+		keys   = []string{f.names.tzid}
+		values = map[string][]byte{}
+		err    error
+	)
+	if values[f.names.tzid], err = json.Marshal(r.tzid); err != nil {
+		return err
+	}
+	if f.names.offset != "" {
+		keys = append(keys, f.names.offset)
+		if values[f.names.offset], err = json.Marshal(r.offset); err != nil {
+			return err
+		}
+	}
+
+	// Overwrite the keys the object already has in place.
+	var pos int
+	var found = map[string]bool{}
+	for _, m := range r.members {
+		if v, ok := values[m.key]; ok {
+			f.w.Write(r.raw[pos:m.start])
+			f.w.Write(v)
+			pos, found[m.key] = m.end, true
+		}
+	}
+
+	// Splice the rest in before the closing brace, after a comma unless the object is empty.
+	var obj = bytes.TrimSpace(r.raw[pos : len(r.raw)-1])
+	f.w.Write(obj)
+	var empty = len(r.members) == 0
+	for _, k := range keys {
+		if found[k] {
+			continue
+		}
+		if !empty {
+			f.w.WriteByte(',')
+		}
+		b, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		f.w.Write(b)
+		f.w.WriteByte(':')
+		f.w.Write(values[k])
+		found[k], empty = true, false
+	}
+	f.w.WriteByte('}')
+	return f.w.WriteByte('\n')
+}
+
+func (f *ndjsonFormat) reject(r *record) error {
+	b, err := json.Marshal(struct {
+		Line   int    `json:"line"`
+		Error  string `json:"error"`
+		Record string `json:"record"`
+	}{Line: r.line, Error: r.err.Error(), Record: string(r.raw)})
+	if err != nil {
+		return err
+	}
+	f.rejects.Write(b)
+	return f.rejects.WriteByte('\n')
+}
+
+func (f *ndjsonFormat) flush() error {
+	if err := f.w.Flush(); err != nil {
+		return err
+	}
+	return f.rejects.Flush()
+}
+
+// columnNames are the names of the columns or keys added to each record. offset is empty when no
+// offset is added.
+type columnNames struct {
+	tzid   string
+	offset string
+}
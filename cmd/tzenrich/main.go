@@ -0,0 +1,131 @@
+// Command tzenrich adds a time zone column to CSV or NDJSON records.
+//
+//	tzenrich -lat latitude -lon longitude < fixes.csv > fixes-tz.csv
+//	tzenrich -format ndjson -offset -rejects bad.ndjson < fixes.ndjson > fixes-tz.ndjson
+//
+// Columns are chosen by header name or zero based index. Records are looked up in parallel and
+// written in input order. Rows that cannot be parsed or fall outside every time zone are written to
+// the rejects file with the reason instead of stopping the run.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/catmullet/tz"
+	"github.com/catmullet/tz/internal/lookupflags"
+	"io"
+	"os"
+	"runtime"
+	"time"
+)
+
+var newLookup = func(opts ...tz.Option) (tz.TimeZoneLookup, error) {
+	return tz.NewTZ(opts...)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		flags       = flag.NewFlagSet("tzenrich", flag.ContinueOnError)
+		inPath      = flags.String("in", "", "input file, stdin when empty")
+		outPath     = flags.String("out", "", "output file, stdout when empty")
+		rejectsPath = flags.String("rejects", "", "file for rejected records, discarded when empty")
+		formatName  = flags.String("format", "csv", "record format, csv or ndjson")
+		header      = flags.Bool("header", true, "csv input starts with a header row")
+		latCol      = flags.String("lat", "lat", "latitude column name or zero based index")
+		lonCol      = flags.String("lon", "lon", "longitude column name or zero based index")
+		tzidCol     = flags.String("tzid-column", "tzid", "name of the added time zone column")
+		offset      = flags.Bool("offset", false, "also add the UTC offset of each record's time zone")
+		offsetCol   = flags.String("offset-column", "utc_offset", "name of the added offset column")
+		at          = flags.String("at", "", "RFC 3339 instant for -offset, now when empty")
+		workers     = flags.Int("workers", runtime.NumCPU(), "number of parallel lookups")
+		lookupFlags = lookupflags.Register(flags)
+	)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 || *workers < 1 || (*formatName != "csv" && *formatName != "ndjson") {
+		flags.Usage()
+		return 2
+	}
+
+	opts, err := lookupFlags.Options()
+	if err != nil {
+		fmt.Fprintln(stderr, "tzenrich:", err)
+		return 2
+	}
+
+	var e = &enricher{workers: *workers}
+	var names = columnNames{tzid: *tzidCol}
+	if *offset {
+		t := time.Now()
+		if *at != "" {
+			var err error
+			if t, err = time.Parse(time.RFC3339, *at); err != nil {
+				fmt.Fprintln(stderr, "tzenrich: invalid -at:", err)
+				return 2
+			}
+		}
+		e.offsetAt = &t
+		names.offset = *offsetCol
+	}
+
+	var (
+		in      = stdin
+		out     = stdout
+		rejects = io.Discard
+	)
+	if *inPath != "" {
+		f, err := os.Open(*inPath)
+		if err != nil {
+			fmt.Fprintln(stderr, "tzenrich:", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	for _, o := range []struct {
+		path string
+		w    *io.Writer
+	}{{*outPath, &out}, {*rejectsPath, &rejects}} {
+		if o.path == "" {
+			continue
+		}
+		f, err := os.Create(o.path)
+		if err != nil {
+			fmt.Fprintln(stderr, "tzenrich:", err)
+			return 1
+		}
+		defer f.Close()
+		*o.w = f
+	}
+
+	lookup, err := newLookup(opts...)
+	if err != nil {
+		fmt.Fprintln(stderr, "tzenrich:", err)
+		return 1
+	}
+	e.lookup = lookup
+
+	var f format
+	if *formatName == "ndjson" {
+		f = newNDJSONFormat(in, out, rejects, *latCol, *lonCol, names)
+	} else if f, err = newCSVFormat(in, out, rejects, *header, *latCol, *lonCol, names); err != nil {
+		fmt.Fprintln(stderr, "tzenrich:", err)
+		return 1
+	}
+
+	s, err := e.run(f)
+	if err != nil {
+		fmt.Fprintln(stderr, "tzenrich:", err)
+		return 1
+	}
+	if s.rejected > 0 {
+		fmt.Fprintf(stderr, "tzenrich: wrote %d records, rejected %d\n", s.written, s.rejected)
+	}
+	return 0
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/catmullet/tz"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeLookup puts everything west of the prime meridian in Chicago and everything east in Johannesburg,
+// except the equator which has no time zone.
+type fakeLookup struct{}
+
+func (fakeLookup) TimeZone(lat, lon float64) string {
+	switch {
+	case lat == 0:
+		return ""
+	case lon < 0:
+		return "America/Chicago"
+	default:
+		return "Africa/Johannesburg"
+	}
+}
+
+func (f fakeLookup) Location(lat, lon float64) (*time.Location, error) {
+	return time.LoadLocation(f.TimeZone(lat, lon))
+}
+
+func init() {
+	newLookup = func(opts ...tz.Option) (tz.TimeZoneLookup, error) {
+		return fakeLookup{}, nil
+	}
+}
+
+func runEnrich(t *testing.T, stdin string, args ...string) (stdout, rejects string) {
+	t.Helper()
+	var (
+		path          = filepath.Join(t.TempDir(), "rejects")
+		out, errOut   bytes.Buffer
+		status        = run(append(args, "-rejects", path), strings.NewReader(stdin), &out, &errOut)
+		rejected, err = os.ReadFile(path)
+	)
+	if status != 0 {
+		t.Fatalf("status %d: %s", status, errOut.String())
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out.String(), string(rejected)
+}
+
+func TestEnrichCSV(t *testing.T) {
+	var input = "id,latitude,longitude\n" +
+		"1,41.8976,-87.6205\n" +
+		"2,north,18.4\n" +
+		"3,-33.9249,18.4241\n" +
+		"4,0,10\n" +
+		"5,1\n"
+	stdout, rejects := runEnrich(t, input, "-lat", "latitude", "-lon", "2", "-offset", "-at", "2026-07-01T00:00:00Z")
+
+	if want := "id,latitude,longitude,tzid,utc_offset\n" +
+		"1,41.8976,-87.6205,America/Chicago,-05:00\n" +
+		"3,-33.9249,18.4241,Africa/Johannesburg,+02:00\n"; stdout != want {
+		t.Errorf("got\n%s\nexpected\n%s", stdout, want)
+	}
+	if want := "id,latitude,longitude,error\n" +
+		"2,north,18.4,\"invalid latitude \"\"north\"\"\"\n" +
+		"4,0,10,\"no time zone found at 0, 10\"\n" +
+		"5,1,row 6 has 2 columns\n"; rejects != want {
+		t.Errorf("got rejects\n%s\nexpected\n%s", rejects, want)
+	}
+}
+
+func TestEnrichCSVWithoutHeader(t *testing.T) {
+	stdout, _ := runEnrich(t, "a,-87.6,41.9\nb,18.4,-33.9\n", "-header=false", "-lat", "2", "-lon", "1")
+	if want := "a,-87.6,41.9,America/Chicago\nb,18.4,-33.9,Africa/Johannesburg\n"; stdout != want {
+		t.Errorf("got\n%s", stdout)
+	}
+}
+
+func TestEnrichNDJSON(t *testing.T) {
+	var input = `{"id": 1, "pos": {"x": 1}, "lat": 41.8976, "lon": -87.6205}` + "\n" +
+		`{"id": 2, "lat": "-33.9249", "lon": "18.4241"}` + "\n" +
+		"\n" +
+		`{"id": 3, "lat": 0, "lon": 10}` + "\n" +
+		`not json` + "\n"
+	stdout, rejects := runEnrich(t, input, "-format", "ndjson", "-tzid-column", "zone")
+
+	if want := `{"id": 1, "pos": {"x": 1}, "lat": 41.8976, "lon": -87.6205,"zone":"America/Chicago"}` + "\n" +
+		`{"id": 2, "lat": "-33.9249", "lon": "18.4241","zone":"Africa/Johannesburg"}` + "\n"; stdout != want {
+		t.Errorf("got\n%s\nexpected\n%s", stdout, want)
+	}
+	if !strings.Contains(rejects, `"line":4,"error":"no time zone found at 0, 10"`) ||
+		!strings.Contains(rejects, `"line":5,"error":"invalid JSON object`) {
+		t.Errorf("got rejects\n%s", rejects)
+	}
+}
+
+func TestEnrichKeepsOrder(t *testing.T) {
+	var in, want strings.Builder
+	in.WriteString("n,lat,lon\n")
+	want.WriteString("n,lat,lon,tzid\n")
+	for i := 0; i < 20*batchSize+7; i++ {
+		lon := float64(i%7 - 3)
+		fmt.Fprintf(&in, "%d,10,%v\n", i, lon)
+		fmt.Fprintf(&want, "%d,10,%v,%s\n", i, lon, fakeLookup{}.TimeZone(10, lon))
+	}
+	if stdout, _ := runEnrich(t, in.String(), "-workers", "8"); stdout != want.String() {
+		t.Error("output is not in input order")
+	}
+}
+
+func TestEnrichUsage(t *testing.T) {
+	for _, args := range [][]string{
+		{"-format", "xml"},
+		{"-workers", "0"},
+		{"-offset", "-at", "yesterday"},
+		{"extra"},
+	} {
+		var out, errOut bytes.Buffer
+		if status := run(args, strings.NewReader(""), &out, &errOut); status != 2 {
+			t.Errorf("%v: status %d", args, status)
+		}
+	}
+
+	var out, errOut bytes.Buffer
+	if status := run([]string{"-lat", "latitude"}, strings.NewReader("id,lat,lon\n"), &out, &errOut); status != 1 {
+		t.Errorf("missing column: status %d", status)
+	}
+}
+
+func TestEnrichNDJSONOverwrite(t *testing.T) {
+	var input = `{"tzid": "UTC", "lat": 41.8976, "lon": -87.6205}` + "\n" +
+		`{"lat": -33.9249, "utc_offset": null, "lon": 18.4241, "tzid": "x", "tzid": "y"}` + "\n"
+	stdout, _ := runEnrich(t, input, "-format", "ndjson", "-offset", "-at", "2026-07-01T00:00:00Z")
+
+	if want := `{"tzid": "America/Chicago", "lat": 41.8976, "lon": -87.6205,"utc_offset":"-05:00"}` + "\n" +
+		`{"lat": -33.9249, "utc_offset": "+02:00", "lon": 18.4241, "tzid": "Africa/Johannesburg", "tzid": "Africa/Johannesburg"}` + "\n"; stdout != want {
+		t.Errorf("got\n%s\nexpected\n%s", stdout, want)
+	}
+}
+
+// blockingLookup holds up lookups at latitude 20 until release is closed.
+type blockingLookup struct {
+	fakeLookup
+	release chan struct{}
+}
+
+func (b *blockingLookup) TimeZone(lat, lon float64) string {
+	if lat == 20 {
+		<-b.release
+	}
+	return b.fakeLookup.TimeZone(lat, lon)
+}
+
+// countingFormat produces n records, the first at latitude 20, and counts how many have been read.
+type countingFormat struct {
+	n     int
+	reads int64
+}
+
+func (f *countingFormat) read() (*record, error) {
+	switch n := atomic.AddInt64(&f.reads, 1); {
+	case n > int64(f.n):
+		return nil, io.EOF
+	case n == 1:
+		return &record{lat: 20, lon: 10}, nil
+	default:
+		return &record{lat: 10, lon: 10}, nil
+	}
+}
+
+func (f *countingFormat) write(*record) error  { return nil }
+func (f *countingFormat) reject(*record) error { return nil }
+func (f *countingFormat) flush() error         { return nil }
+
+func TestEnrichBoundsInFlight(t *testing.T) {
+	var (
+		workers = 4
+		lookup  = &blockingLookup{release: make(chan struct{})}
+		f       = &countingFormat{n: 100 * batchSize}
+		e       = &enricher{lookup: lookup, workers: workers}
+		done    = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		if s, err := e.run(f); err != nil || s.written != f.n {
+			t.Errorf("got %d written, %v, expected %d", s.written, err, f.n)
+		}
+	}()
+
+	// While the first batch is held up, the reader may fill the in flight batches and one more.
+	time.Sleep(100 * time.Millisecond)
+	if reads, limit := atomic.LoadInt64(&f.reads), int64((workers*2+1)*batchSize); reads > limit {
+		t.Errorf("read %d records ahead of the writer, expected at most %d", reads, limit)
+	}
+	close(lookup.release)
+	<-done
+}
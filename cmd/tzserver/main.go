@@ -0,0 +1,74 @@
+// Command tzserver serves time zone lookups over HTTP.
+//
+//	GET  /v1/timezone?lat=41.8976&lon=-87.6205
+//	POST /v1/timezone/batch   [{"lat": 41.8976, "lon": -87.6205}, ...]
+//	GET  /healthz
+//	GET  /readyz
+//
+// The listener starts immediately and /readyz reports 503 until the time zone polygons are loaded.
+// SIGINT and SIGTERM stop accepting connections and wait for in-flight requests to finish.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/catmullet/tz"
+	"github.com/catmullet/tz/internal/lookupflags"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	var (
+		addr        = flag.String("addr", ":8080", "address to listen on")
+		lookupFlags = lookupflags.Register(flag.CommandLine)
+		grace       = flag.Duration("shutdown-timeout", 15*time.Second, "time to wait for requests to finish on shutdown")
+	)
+	flag.Parse()
+
+	opts, err := lookupFlags.Options()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var (
+		s   = newServer()
+		srv = &http.Server{Addr: *addr, Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	)
+
+	go func() {
+		start := time.Now()
+		lookup, err := tz.NewTZ(opts...)
+		if err != nil {
+			log.Fatalln("failed to load time zones:", err)
+		}
+		s.setLookup(lookup)
+		log.Printf("loaded time zones in %v", time.Since(start))
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var drained = make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *grace)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Println("shutdown:", err)
+		}
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalln(err)
+	}
+	// ListenAndServe returns as soon as shutdown starts, so wait for in-flight requests.
+	<-drained
+}
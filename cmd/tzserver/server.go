@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/catmullet/tz"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxBatchBytes bounds the size of a batch request body.
+const maxBatchBytes = 8 << 20
+
+type coordinate struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// response is the JSON body for one lookup. Offset is the UTC offset in seconds at the time of the request.
+type response struct {
+	Lat          float64 `json:"lat"`
+	Lon          float64 `json:"lon"`
+	TZID         string  `json:"tzid,omitempty"`
+	Offset       int     `json:"offset"`
+	Abbreviation string  `json:"abbreviation,omitempty"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// server answers lookups over HTTP. It reports not ready until a lookup is set, so the
+// listener can come up while the time zone polygons are still loading.
+type server struct {
+	mux    *http.ServeMux
+	now    func() time.Time
+	mu     sync.RWMutex
+	lookup tz.TimeZoneLookup
+}
+
+func newServer() *server {
+	s := &server{mux: http.NewServeMux(), now: time.Now}
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/readyz", s.handleReady)
+	s.mux.HandleFunc("/v1/timezone", s.handleTimeZone)
+	s.mux.HandleFunc("/v1/timezone/batch", s.handleBatch)
+	return s
+}
+
+func (s *server) setLookup(lookup tz.TimeZoneLookup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookup = lookup
+}
+
+func (s *server) getLookup() tz.TimeZoneLookup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lookup
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *server) handleReady(w http.ResponseWriter, _ *http.Request) {
+	if s.getLookup() == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "loading"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (s *server) handleTimeZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	lookup := s.getLookup()
+	if lookup == nil {
+		writeError(w, http.StatusServiceUnavailable, "time zones are still loading")
+		return
+	}
+
+	var c coordinate
+	var err error
+	if c.Lat, err = strconv.ParseFloat(r.URL.Query().Get("lat"), 64); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid lat")
+		return
+	}
+	if c.Lon, err = strconv.ParseFloat(r.URL.Query().Get("lon"), 64); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid lon")
+		return
+	}
+
+	resp := s.resolve(lookup, c, s.now())
+	if resp.Error != "" {
+		writeJSON(w, http.StatusNotFound, resp)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	lookup := s.getLookup()
+	if lookup == nil {
+		writeError(w, http.StatusServiceUnavailable, "time zones are still loading")
+		return
+	}
+
+	var coords []coordinate
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBytes)).Decode(&coords); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %v", err))
+		return
+	}
+
+	var (
+		now       = s.now()
+		responses = make([]response, len(coords))
+	)
+	for i, c := range coords {
+		responses[i] = s.resolve(lookup, c, now)
+	}
+	writeJSON(w, http.StatusOK, responses)
+}
+
+func (s *server) resolve(lookup tz.TimeZoneLookup, c coordinate, now time.Time) response {
+	resp := response{Lat: c.Lat, Lon: c.Lon, TZID: lookup.TimeZone(c.Lat, c.Lon)}
+	if resp.TZID == "" {
+		resp.Error = "no time zone found"
+		return resp
+	}
+	loc, err := tz.LoadLocation(resp.TZID)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	resp.Abbreviation, resp.Offset = now.In(loc).Zone()
+	return resp
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeLookup map[[2]float64]string
+
+func (f fakeLookup) TimeZone(lat, lon float64) string {
+	return f[[2]float64{lat, lon}]
+}
+
+func (f fakeLookup) Location(lat, lon float64) (*time.Location, error) {
+	return time.LoadLocation(f.TimeZone(lat, lon))
+}
+
+func newTestServer(t *testing.T) (*server, *httptest.Server) {
+	s := newServer()
+	s.now = func() time.Time {
+		return time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+	}
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func decode(t *testing.T, resp *http.Response, v interface{}) {
+	t.Helper()
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got content type %q", ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	for path, status := range map[string]int{"/healthz": 200, "/readyz": 503, "/v1/timezone?lat=1&lon=2": 503} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("%s before loading: got %d, expected %d", path, resp.StatusCode, status)
+		}
+	}
+
+	s.setLookup(fakeLookup{})
+	resp, err := http.Get(ts.URL + "/readyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("readyz after loading: got %d", resp.StatusCode)
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.setLookup(fakeLookup{{41.8976, -87.6205}: "America/Chicago"})
+
+	var tests = []struct {
+		Query  string
+		Status int
+		Resp   response
+	}{
+		{Query: "lat=41.8976&lon=-87.6205", Status: 200,
+			Resp: response{Lat: 41.8976, Lon: -87.6205, TZID: "America/Chicago", Offset: -5 * 3600, Abbreviation: "CDT"}},
+		{Query: "lat=0&lon=0", Status: 404, Resp: response{Error: "no time zone found"}},
+		{Query: "lat=north&lon=0", Status: 400, Resp: response{Error: "invalid lat"}},
+		{Query: "lat=1", Status: 400, Resp: response{Error: "invalid lon"}},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + "/v1/timezone?" + tt.Query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.Status {
+			t.Errorf("%s: got status %d, expected %d", tt.Query, resp.StatusCode, tt.Status)
+		}
+		var got response
+		decode(t, resp, &got)
+		if got != tt.Resp {
+			t.Errorf("%s: got %+v, expected %+v", tt.Query, got, tt.Resp)
+		}
+	}
+
+	resp, err := http.Post(ts.URL+"/v1/timezone?lat=1&lon=2", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST: got status %d", resp.StatusCode)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	s, ts := newTestServer(t)
+	s.setLookup(fakeLookup{
+		{41.8976, -87.6205}: "America/Chicago",
+		{-33.9249, 18.4241}: "Africa/Johannesburg",
+	})
+
+	body := `[{"lat": 41.8976, "lon": -87.6205}, {"lat": 0, "lon": 0}, {"lat": -33.9249, "lon": 18.4241}]`
+	resp, err := http.Post(ts.URL+"/v1/timezone/batch", "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d", resp.StatusCode)
+	}
+	var got []response
+	decode(t, resp, &got)
+	var want = []response{
+		{Lat: 41.8976, Lon: -87.6205, TZID: "America/Chicago", Offset: -5 * 3600, Abbreviation: "CDT"},
+		{Error: "no time zone found"},
+		{Lat: -33.9249, Lon: 18.4241, TZID: "Africa/Johannesburg", Offset: 2 * 3600, Abbreviation: "SAST"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%d: got %+v, expected %+v", i, got[i], want[i])
+		}
+	}
+
+	for _, body := range []string{`{"lat": 1}`, `not json`} {
+		resp, err := http.Post(ts.URL+"/v1/timezone/batch", "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: got status %d", body, resp.StatusCode)
+		}
+	}
+}
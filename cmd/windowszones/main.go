@@ -0,0 +1,107 @@
+// Command windowszones regenerates internal/zoneinfo/windowsZones.txt, the Windows time zone mapping, from a
+// local copy of the Unicode CLDR data.
+//
+//	go run ./cmd/windowszones -cldr path/to/cldr -out internal/zoneinfo/windowsZones.txt
+//
+// It reads common/supplemental/windowsZones.xml and, when present, common/bcp47/timezone.xml, which maps
+// the CLDR names of zones that IANA has renamed, such as Asia/Calcutta, to their current IANA names.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/catmullet/tz/internal/cldr"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type windowsZones struct {
+	MapTimezones struct {
+		OtherVersion string `xml:"otherVersion,attr"`
+		TypeVersion  string `xml:"typeVersion,attr"`
+		MapZones     []struct {
+			Other     string `xml:"other,attr"`
+			Territory string `xml:"territory,attr"`
+			Type      string `xml:"type,attr"`
+		} `xml:"mapZone"`
+	} `xml:"windowsZones>mapTimezones"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	var (
+		flags   = flag.NewFlagSet("windowszones", flag.ContinueOnError)
+		cldrDir = flags.String("cldr", "", "root of the CLDR data, containing common/supplemental")
+		out     = flags.String("out", "", "file to write, stdout by default")
+	)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *cldrDir == "" || flags.NArg() > 0 {
+		fmt.Fprintln(stderr, "usage: windowszones -cldr dir [-out file]")
+		return 2
+	}
+
+	var zones windowsZones
+	if err := cldr.ReadXML(filepath.Join(*cldrDir, "common", "supplemental", "windowsZones.xml"), &zones); err != nil {
+		fmt.Fprintf(stderr, "windowszones: %v\n", err)
+		return 1
+	}
+	iana, err := cldr.IANANames(filepath.Join(*cldrDir, "common"))
+	if err != nil {
+		fmt.Fprintf(stderr, "windowszones: %v\n", err)
+		return 1
+	}
+
+	var lines []string
+	for _, z := range zones.MapTimezones.MapZones {
+		var tzids = strings.Fields(z.Type)
+		if z.Other == "" || z.Territory == "" || len(tzids) == 0 {
+			fmt.Fprintf(stderr, "windowszones: incomplete mapZone %+v\n", z)
+			return 1
+		}
+		for i, tzid := range tzids {
+			if name, ok := iana[tzid]; ok {
+				tzids[i] = name
+			}
+		}
+		lines = append(lines, z.Other+"\t"+z.Territory+"\t"+strings.Join(tzids, " "))
+	}
+	if len(lines) == 0 {
+		fmt.Fprintln(stderr, "windowszones: no mapZone elements found")
+		return 1
+	}
+	sort.Strings(lines)
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "# Windows time zone IDs and the IANA time zones of each territory, the first being the territory's")
+	fmt.Fprintln(&b, "# canonical zone and territory 001 the default. Generated by cmd/windowszones from CLDR windowsZones.xml.")
+	if v := zones.MapTimezones; v.TypeVersion != "" || v.OtherVersion != "" {
+		fmt.Fprintf(&b, "# typeVersion %s otherVersion %s\n", v.TypeVersion, v.OtherVersion)
+	} else {
+		// Copies of CLDR converted from other formats, such as ICU's, can lose the attributes.
+		fmt.Fprintln(stderr, "windowszones: warning: mapTimezones has no typeVersion or otherVersion, the header will not record them")
+	}
+	for _, line := range lines {
+		fmt.Fprintln(&b, line)
+	}
+
+	if *out == "" {
+		_, err = stdout.Write(b.Bytes())
+	} else {
+		err = os.WriteFile(*out, b.Bytes(), 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "windowszones: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testWindowsZones = `<?xml version="1.0" encoding="UTF-8" ?>
+<supplementalData>
+	<version number="$Revision$"/>
+	<windowsZones>
+		<mapTimezones otherVersion="7e11800" typeVersion="2021a">
+			<mapZone other="India Standard Time" territory="001" type="Asia/Calcutta"/>
+			<mapZone other="India Standard Time" territory="IN" type="Asia/Calcutta"/>
+			<mapZone other="Central Standard Time" territory="CA" type="America/Winnipeg America/Rankin_Inlet"/>
+			<mapZone other="Central Standard Time" territory="001" type="America/Chicago"/>
+		</mapTimezones>
+	</windowsZones>
+</supplementalData>
+`
+
+const testTimezoneKeys = `<?xml version="1.0" encoding="UTF-8" ?>
+<ldmlBCP47>
+	<keyword>
+		<key name="tz" description="Time zone key">
+			<type name="inccu" description="Kolkata, India" alias="Asia/Calcutta Asia/Kolkata" iana="Asia/Kolkata"/>
+			<type name="uschi" description="Chicago, United States" alias="America/Chicago US/Central"/>
+		</key>
+	</keyword>
+</ldmlBCP47>
+`
+
+func writeCLDR(t *testing.T, withKeys bool) string {
+	var dir = t.TempDir()
+	var files = map[string]string{filepath.Join("common", "supplemental", "windowsZones.xml"): testWindowsZones}
+	if withKeys {
+		files[filepath.Join("common", "bcp47", "timezone.xml")] = testTimezoneKeys
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-cldr", writeCLDR(t, true)}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	var want = []string{
+		"# typeVersion 2021a otherVersion 7e11800",
+		"Central Standard Time\t001\tAmerica/Chicago",
+		"Central Standard Time\tCA\tAmerica/Winnipeg America/Rankin_Inlet",
+		"India Standard Time\t001\tAsia/Kolkata",
+		"India Standard Time\tIN\tAsia/Kolkata",
+	}
+	var lines = strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != len(want)+2 {
+		t.Fatalf("got %q", stdout.String())
+	}
+	for i, line := range lines[2:] {
+		if line != want[i] {
+			t.Errorf("line %d: got %q, expected %q", i+3, line, want[i])
+		}
+	}
+}
+
+func TestRunWithoutKeys(t *testing.T) {
+	var out = filepath.Join(t.TempDir(), "windowsZones.txt")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-cldr", writeCLDR(t, false), "-out", out}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "India Standard Time\tIN\tAsia/Calcutta\n") || stdout.Len() != 0 {
+		t.Errorf("got %q", b)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("without -cldr: got exit %d", code)
+	}
+	if code := run([]string{"-cldr", t.TempDir()}, &stdout, &stderr); code != 1 {
+		t.Errorf("missing files: got exit %d", code)
+	}
+}
+
+func TestRunWithoutVersions(t *testing.T) {
+	var dir = writeCLDR(t, false)
+	var path = filepath.Join(dir, "common", "supplemental", "windowsZones.xml")
+	if err := os.WriteFile(path, []byte(strings.Replace(testWindowsZones, ` otherVersion="7e11800" typeVersion="2021a"`, "", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-cldr", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "typeVersion") || !strings.Contains(stderr.String(), "no typeVersion or otherVersion") {
+		t.Errorf("got %q, warning %q", stdout.String(), stderr.String())
+	}
+}
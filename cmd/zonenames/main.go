@@ -0,0 +1,259 @@
+// Command zonenames regenerates the data of package tznames, the CLDR metazones and localized time zone
+// names, from a local copy of the Unicode CLDR data.
+//
+//	go run ./cmd/zonenames -cldr path/to/cldr -out tznames/data
+//
+// It reads common/supplemental/metaZones.xml, common/bcp47/timezone.xml when present, which maps the CLDR
+// names of zones that IANA has renamed to their current IANA names, and common/main/root.xml and
+// common/main/<language>.xml for each language.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/catmullet/tz/internal/cldr"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultLanguages are the languages generated when -languages is not given.
+const defaultLanguages = "ar,cs,da,de,en,es,fi,fr,hi,it,ja,ko,nl,pl,pt,ru,sv,tr,uk,zh"
+
+// openEnded is the end CLDR gives the metazone periods still in use.
+const openEnded = "9999-12-31 23:59"
+
+// inherited marks CLDR values that are inherited from the parent locale.
+var inherited = map[string]bool{"↑↑↑": true, "∅∅∅": true}
+
+type metaZonesXML struct {
+	Timezones []struct {
+		Type  string `xml:"type,attr"`
+		Zones []struct {
+			Metazone string `xml:"mzone,attr"`
+			From     string `xml:"from,attr"`
+			To       string `xml:"to,attr"`
+		} `xml:"usesMetazone"`
+	} `xml:"metaZones>metazoneInfo>timezone"`
+}
+
+type ldmlXML struct {
+	Names struct {
+		HourFormat    string       `xml:"hourFormat"`
+		GMTFormat     string       `xml:"gmtFormat"`
+		GMTZeroFormat string       `xml:"gmtZeroFormat"`
+		RegionFormats []typedValue `xml:"regionFormat"`
+		Zones         []zoneXML    `xml:"zone"`
+		Metazones     []zoneXML    `xml:"metazone"`
+	} `xml:"dates>timeZoneNames"`
+}
+
+type typedValue struct {
+	Type  string `xml:"type,attr"`
+	Alt   string `xml:"alt,attr"`
+	Value string `xml:",chardata"`
+}
+
+type zoneXML struct {
+	Type          string       `xml:"type,attr"`
+	Long          namesXML     `xml:"long"`
+	Short         namesXML     `xml:"short"`
+	ExemplarCitys []typedValue `xml:"exemplarCity"`
+}
+
+type namesXML struct {
+	Generic  string `xml:"generic"`
+	Standard string `xml:"standard"`
+	Daylight string `xml:"daylight"`
+}
+
+// period and locale match the JSON read by package tznames.
+type period struct {
+	Metazone string `json:"metazone"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+}
+
+type locale struct {
+	HourFormat    string           `json:"hourFormat"`
+	GMTFormat     string           `json:"gmtFormat"`
+	GMTZeroFormat string           `json:"gmtZeroFormat"`
+	RegionFormat  string           `json:"regionFormat"`
+	Metazones     map[string]names `json:"metazones"`
+	Zones         map[string]names `json:"zones"`
+}
+
+type names struct {
+	LongGeneric   string `json:"lg,omitempty"`
+	LongStandard  string `json:"ls,omitempty"`
+	LongDaylight  string `json:"ld,omitempty"`
+	ShortGeneric  string `json:"sg,omitempty"`
+	ShortStandard string `json:"ss,omitempty"`
+	ShortDaylight string `json:"sd,omitempty"`
+	ExemplarCity  string `json:"ec,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	var (
+		flags     = flag.NewFlagSet("zonenames", flag.ContinueOnError)
+		cldrDir   = flags.String("cldr", "", "root of the CLDR data, containing common/main and common/supplemental")
+		out       = flags.String("out", filepath.Join("tznames", "data"), "directory to write the data to")
+		languages = flags.String("languages", defaultLanguages, "comma separated languages to generate")
+	)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *cldrDir == "" || flags.NArg() > 0 {
+		fmt.Fprintln(stderr, "usage: zonenames -cldr dir [-out dir] [-languages list]")
+		return 2
+	}
+	if err := generate(filepath.Join(*cldrDir, "common"), *out, strings.Split(*languages, ",")); err != nil {
+		fmt.Fprintf(stderr, "zonenames: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func generate(common, out string, languages []string) error {
+	iana, err := cldr.IANANames(common)
+	if err != nil {
+		return err
+	}
+	var ianaName = func(tzid string) string {
+		if name, ok := iana[tzid]; ok {
+			return name
+		}
+		return tzid
+	}
+
+	var mz metaZonesXML
+	if err := cldr.ReadXML(filepath.Join(common, "supplemental", "metaZones.xml"), &mz); err != nil {
+		return err
+	}
+	var metazones = make(map[string][]period)
+	for _, tz := range mz.Timezones {
+		for _, z := range tz.Zones {
+			p := period{Metazone: z.Metazone, From: z.From, To: z.To}
+			if p.To == openEnded {
+				p.To = ""
+			}
+			metazones[ianaName(tz.Type)] = append(metazones[ianaName(tz.Type)], p)
+		}
+	}
+	if len(metazones) == 0 {
+		return fmt.Errorf("no metazones found")
+	}
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(out, "metazones.json"), metazones); err != nil {
+		return err
+	}
+
+	root, err := readLocale(common, "root", ianaName)
+	if err != nil {
+		return err
+	}
+	for _, lang := range languages {
+		lang = strings.TrimSpace(lang)
+		l, err := readLocale(common, lang, ianaName)
+		if err != nil {
+			return err
+		}
+		l.inherit(root)
+		if err := writeJSON(filepath.Join(out, lang+".json"), l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLocale(common, lang string, ianaName func(string) string) (locale, error) {
+	var doc ldmlXML
+	if err := cldr.ReadXML(filepath.Join(common, "main", lang+".xml"), &doc); err != nil {
+		return locale{}, err
+	}
+	var l = locale{
+		HourFormat:    value(doc.Names.HourFormat),
+		GMTFormat:     value(doc.Names.GMTFormat),
+		GMTZeroFormat: value(doc.Names.GMTZeroFormat),
+		Metazones:     make(map[string]names),
+		Zones:         make(map[string]names),
+	}
+	// Only the generic region format is used, for generic names that are missing.
+	for _, f := range doc.Names.RegionFormats {
+		if f.Alt == "" && f.Type == "" {
+			l.RegionFormat = value(f.Value)
+		}
+	}
+	for _, z := range doc.Names.Metazones {
+		if n := z.names(); n != (names{}) {
+			l.Metazones[z.Type] = n
+		}
+	}
+	for _, z := range doc.Names.Zones {
+		if n := z.names(); n != (names{}) {
+			l.Zones[ianaName(z.Type)] = n
+		}
+	}
+	return l, nil
+}
+
+func (z zoneXML) names() names {
+	var n = names{
+		LongGeneric:   value(z.Long.Generic),
+		LongStandard:  value(z.Long.Standard),
+		LongDaylight:  value(z.Long.Daylight),
+		ShortGeneric:  value(z.Short.Generic),
+		ShortStandard: value(z.Short.Standard),
+		ShortDaylight: value(z.Short.Daylight),
+	}
+	for _, city := range z.ExemplarCitys {
+		if city.Alt == "" {
+			n.ExemplarCity = value(city.Value)
+		}
+	}
+	return n
+}
+
+// inherit fills the formats a locale leaves out from its parent.
+func (l *locale) inherit(parent locale) {
+	for _, f := range []struct{ v, p *string }{
+		{&l.HourFormat, &parent.HourFormat},
+		{&l.GMTFormat, &parent.GMTFormat},
+		{&l.GMTZeroFormat, &parent.GMTZeroFormat},
+		{&l.RegionFormat, &parent.RegionFormat},
+	} {
+		if *f.v == "" {
+			*f.v = *f.p
+		}
+	}
+}
+
+func value(s string) string {
+	s = strings.TrimSpace(s)
+	if inherited[s] {
+		return ""
+	}
+	return s
+}
+
+func writeJSON(path string, v interface{}) error {
+	var b bytes.Buffer
+	var enc = json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0644)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testMetaZones = `<?xml version="1.0" encoding="UTF-8" ?>
+<supplementalData>
+	<metaZones>
+		<metazoneInfo>
+			<timezone type="Asia/Calcutta">
+				<usesMetazone mzone="India"/>
+			</timezone>
+			<timezone type="America/Indiana/Knox">
+				<usesMetazone to="1991-10-27 07:00" mzone="America_Central"/>
+				<usesMetazone to="2006-04-02 07:00" from="1991-10-27 07:00" mzone="America_Eastern"/>
+				<usesMetazone from="2006-04-02 07:00" to="9999-12-31 23:59" mzone="America_Central"/>
+			</timezone>
+		</metazoneInfo>
+	</metaZones>
+</supplementalData>
+`
+
+const testTimezoneKeys = `<?xml version="1.0" encoding="UTF-8" ?>
+<ldmlBCP47>
+	<keyword>
+		<key name="tz" description="Time zone key">
+			<type name="inccu" description="Kolkata, India" alias="Asia/Calcutta Asia/Kolkata" iana="Asia/Kolkata"/>
+		</key>
+	</keyword>
+</ldmlBCP47>
+`
+
+const testRoot = `<?xml version="1.0" encoding="UTF-8" ?>
+<ldml>
+	<dates>
+		<timeZoneNames>
+			<hourFormat>+HH:mm;-HH:mm</hourFormat>
+			<gmtFormat>GMT{0}</gmtFormat>
+			<gmtZeroFormat>GMT</gmtZeroFormat>
+			<regionFormat>{0}</regionFormat>
+			<regionFormat type="daylight">{0} (+1)</regionFormat>
+			<regionFormat type="standard">{0} (+0)</regionFormat>
+		</timeZoneNames>
+	</dates>
+</ldml>
+`
+
+const testGerman = `<?xml version="1.0" encoding="UTF-8" ?>
+<ldml>
+	<dates>
+		<timeZoneNames>
+			<regionFormat>{0} (Ortszeit)</regionFormat>
+			<zone type="Asia/Calcutta">
+				<exemplarCity>Kalkutta</exemplarCity>
+				<exemplarCity alt="secondary">Kolkata</exemplarCity>
+			</zone>
+			<zone type="Europe/London">
+				<long>
+					<daylight>Britische Sommerzeit</daylight>
+				</long>
+			</zone>
+			<zone type="Europe/Paris">
+				<exemplarCity>↑↑↑</exemplarCity>
+			</zone>
+			<metazone type="India">
+				<long>
+					<standard>Indische Normalzeit</standard>
+				</long>
+			</metazone>
+		</timeZoneNames>
+	</dates>
+</ldml>
+`
+
+func writeCLDR(t *testing.T) string {
+	var dir = t.TempDir()
+	var files = map[string]string{
+		filepath.Join("common", "supplemental", "metaZones.xml"): testMetaZones,
+		filepath.Join("common", "bcp47", "timezone.xml"):         testTimezoneKeys,
+		filepath.Join("common", "main", "root.xml"):              testRoot,
+		filepath.Join("common", "main", "de.xml"):                testGerman,
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readJSON(t *testing.T, path string, v interface{}) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var out = t.TempDir()
+	var stderr bytes.Buffer
+	if code := run([]string{"-cldr", writeCLDR(t), "-out", out, "-languages", "de"}, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+
+	var metazones map[string][]period
+	readJSON(t, filepath.Join(out, "metazones.json"), &metazones)
+	var wantMetazones = map[string][]period{
+		"Asia/Kolkata": {{Metazone: "India"}},
+		"America/Indiana/Knox": {
+			{Metazone: "America_Central", To: "1991-10-27 07:00"},
+			{Metazone: "America_Eastern", From: "1991-10-27 07:00", To: "2006-04-02 07:00"},
+			{Metazone: "America_Central", From: "2006-04-02 07:00"},
+		},
+	}
+	if !reflect.DeepEqual(metazones, wantMetazones) {
+		t.Errorf("metazones: got %v, expected %v", metazones, wantMetazones)
+	}
+
+	var de locale
+	readJSON(t, filepath.Join(out, "de.json"), &de)
+	var wantDE = locale{
+		HourFormat:    "+HH:mm;-HH:mm",
+		GMTFormat:     "GMT{0}",
+		GMTZeroFormat: "GMT",
+		RegionFormat:  "{0} (Ortszeit)",
+		Metazones:     map[string]names{"India": {LongStandard: "Indische Normalzeit"}},
+		Zones: map[string]names{
+			"Asia/Kolkata":  {ExemplarCity: "Kalkutta"},
+			"Europe/London": {LongDaylight: "Britische Sommerzeit"},
+		},
+	}
+	if !reflect.DeepEqual(de, wantDE) {
+		t.Errorf("de: got %+v, expected %+v", de, wantDE)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run(nil, &stderr); code != 2 {
+		t.Errorf("no -cldr: got exit %d, expected 2", code)
+	}
+	if code := run([]string{"-cldr", writeCLDR(t), "-out", t.TempDir(), "-languages", "xx"}, &stderr); code != 1 {
+		t.Errorf("missing language: got exit %d, expected 1", code)
+	}
+	if code := run([]string{"-cldr", t.TempDir(), "-out", t.TempDir()}, &stderr); code != 1 {
+		t.Errorf("missing data: got exit %d, expected 1", code)
+	}
+}
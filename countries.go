@@ -0,0 +1,148 @@
+package tz
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/catmullet/tz/internal/zoneinfo"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+const (
+	zoneTabFilename     = "zone.tab"
+	zone1970TabFilename = "zone1970.tab"
+	iso3166TabFilename  = "iso3166.tab"
+)
+
+// Country is an ISO 3166-1 country.
+type Country struct {
+	// Code is the ISO 3166-1 alpha-2 code, such as US.
+	Code string
+	Name string
+}
+
+// ZoneCountries are the countries using a time zone.
+type ZoneCountries struct {
+	TZID string
+	// Countries are the countries using the zone. The first is the country zone.tab assigns it to, followed
+	// by the other countries zone1970.tab lists for it, so a zone shared by several countries lists them all.
+	Countries []Country
+	// Comment is the tzdb comment on the zone, such as "most of Germany", empty when the country has one zone.
+	Comment string
+}
+
+type zoneTabs struct {
+	zones map[string]ZoneCountries
+	names map[string]string
+}
+
+var (
+	zoneTabsOnce sync.Once
+	zoneTabsData zoneTabs
+	zoneTabsErr  error
+)
+
+func loadZoneTabs() (zoneTabs, error) {
+	zoneTabsOnce.Do(func() {
+		zoneTabsData, zoneTabsErr = parseZoneTabs(zoneinfo.FS)
+	})
+	return zoneTabsData, zoneTabsErr
+}
+
+func parseZoneTabs(fsys fs.FS) (zoneTabs, error) {
+	var zt = zoneTabs{zones: make(map[string]ZoneCountries), names: make(map[string]string)}
+
+	if err := readTab(fsys, iso3166TabFilename, 2, func(fields []string) {
+		zt.names[fields[0]] = fields[1]
+	}); err != nil {
+		return zoneTabs{}, err
+	}
+	// zone.tab assigns each zone to one country, zone1970.tab lists every country using it.
+	if err := readTab(fsys, zoneTabFilename, 3, func(fields []string) {
+		zc := ZoneCountries{TZID: fields[2], Countries: []Country{zt.country(fields[0])}}
+		if len(fields) > 3 {
+			zc.Comment = fields[3]
+		}
+		zt.zones[zc.TZID] = zc
+	}); err != nil {
+		return zoneTabs{}, err
+	}
+	if err := readTab(fsys, zone1970TabFilename, 3, func(fields []string) {
+		zc, ok := zt.zones[fields[2]]
+		if !ok {
+			zc.TZID = fields[2]
+			if len(fields) > 3 {
+				zc.Comment = fields[3]
+			}
+		}
+		for _, code := range strings.Split(fields[0], ",") {
+			if !zc.hasCountry(code) {
+				zc.Countries = append(zc.Countries, zt.country(code))
+			}
+		}
+		zt.zones[zc.TZID] = zc
+	}); err != nil {
+		return zoneTabs{}, err
+	}
+	return zt, nil
+}
+
+// readTab calls fn with the tab separated fields of each line of a tzdb .tab file, skipping comments.
+func readTab(fsys fs.FS, name string, minFields int, fn func(fields []string)) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	var scanner = bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < minFields {
+			return fmt.Errorf("failed to parse %s line %d", name, line)
+		}
+		fn(fields)
+	}
+	return scanner.Err()
+}
+
+func (zt zoneTabs) country(code string) Country {
+	return Country{Code: code, Name: zt.names[code]}
+}
+
+func (zc ZoneCountries) hasCountry(code string) bool {
+	for _, c := range zc.Countries {
+		if c.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeZoneCountries returns the countries using a time zone, given by its name or an alias of it.
+func TimeZoneCountries(tzid string) (ZoneCountries, error) {
+	zt, err := loadZoneTabs()
+	if err != nil {
+		return ZoneCountries{}, fmt.Errorf("failed to load zone tables, %w", err)
+	}
+	if zc, ok := zt.zones[tzid]; ok {
+		return zc, nil
+	}
+	if zc, ok := zt.zones[CanonicalTimeZone(tzid)]; ok {
+		return zc, nil
+	}
+	return ZoneCountries{}, fmt.Errorf("no country for %q", tzid)
+}
+
+// Countries returns the countries using the time zone at a coordinate, see TimeZoneCountries.
+func (fc Collection) Countries(lat, lon float64) (ZoneCountries, error) {
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return ZoneCountries{}, err
+	}
+	return TimeZoneCountries(tzid)
+}
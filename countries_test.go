@@ -0,0 +1,82 @@
+package tz
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTimeZoneCountries(t *testing.T) {
+	var tests = []struct {
+		TZID      string
+		Countries []string
+		Comment   string
+	}{
+		{TZID: "America/Denver", Countries: []string{"US"}, Comment: "Mountain (most areas)"},
+		{TZID: "Europe/Oslo", Countries: []string{"NO"}},
+		{TZID: "Europe/Berlin", Countries: []string{"DE", "DK", "NO", "SE", "SJ"}, Comment: "most of Germany"},
+		{TZID: "Europe/Zurich", Countries: []string{"CH", "DE", "LI"}},
+		{TZID: "Asia/Riyadh", Countries: []string{"SA", "AQ", "KW", "YE"}},
+		{TZID: "Asia/Calcutta", Countries: []string{"IN"}},
+	}
+	for _, test := range tests {
+		zc, err := TimeZoneCountries(test.TZID)
+		if err != nil {
+			t.Errorf("%s: %v", test.TZID, err)
+			continue
+		}
+		var codes []string
+		for _, c := range zc.Countries {
+			codes = append(codes, c.Code)
+		}
+		if !reflect.DeepEqual(codes, test.Countries) || zc.Comment != test.Comment {
+			t.Errorf("%s: got %v %q, expected %v %q", test.TZID, codes, zc.Comment, test.Countries, test.Comment)
+		}
+	}
+
+	zc, _ := TimeZoneCountries("Europe/Zurich")
+	if zc.Countries[0] != (Country{Code: "CH", Name: "Switzerland"}) || zc.Countries[2].Name != "Liechtenstein" {
+		t.Errorf("got %+v", zc.Countries)
+	}
+	for _, tzid := range []string{"Etc/GMT+5", "Mars/Olympus_Mons"} {
+		if _, err := TimeZoneCountries(tzid); err == nil {
+			t.Errorf("%s: expected error", tzid)
+		}
+	}
+}
+
+func TestCountries(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	zc, err := fc.Countries(-17, 178)
+	if err != nil || zc.TZID != "Pacific/Fiji" || len(zc.Countries) != 1 || zc.Countries[0] != (Country{Code: "FJ", Name: "Fiji"}) {
+		t.Errorf("got %+v %v", zc, err)
+	}
+	if _, err := fc.Countries(40.5, -107); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
+
+func TestParseZoneTabs(t *testing.T) {
+	var fsys = fstest.MapFS{
+		iso3166TabFilename:  {Data: []byte("# comment\nAA\tAland\nBB\tBland\n")},
+		zoneTabFilename:     {Data: []byte("AA\t+00+000\tX/One\tnorth\nBB\t+00+000\tX/Two\n")},
+		zone1970TabFilename: {Data: []byte("AA,BB\t+00+000\tX/One\tnorth\nBB\t+00+000\tX/Three\n")},
+	}
+	zt, err := parseZoneTabs(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want = map[string]ZoneCountries{
+		"X/One":   {TZID: "X/One", Countries: []Country{{"AA", "Aland"}, {"BB", "Bland"}}, Comment: "north"},
+		"X/Two":   {TZID: "X/Two", Countries: []Country{{"BB", "Bland"}}},
+		"X/Three": {TZID: "X/Three", Countries: []Country{{"BB", "Bland"}}},
+	}
+	if !reflect.DeepEqual(zt.zones, want) {
+		t.Errorf("got %+v, expected %+v", zt.zones, want)
+	}
+
+	fsys[zoneTabFilename] = &fstest.MapFile{Data: []byte("AA\tX/One\n")}
+	if _, err := parseZoneTabs(fsys); err == nil {
+		t.Error("expected error for short line")
+	}
+}
@@ -0,0 +1,175 @@
+package tz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseDMS parses a coordinate pair written in degrees, minutes and seconds such as 41°53'51"N 87°37'14"W.
+// Minutes and seconds may be left out or carry decimals, the hemisphere may come before or after each
+// value, and without hemispheres a leading minus sign marks south or west, e.g. 41 53 51, -87 37 14.
+// Latitude comes first unless the hemispheres say otherwise.
+func ParseDMS(s string) (lat, lon float64, err error) {
+	var invalid = fmt.Errorf("invalid DMS coordinates %q", s)
+
+	parts, err := splitDMS(s)
+	if err != nil {
+		return 0, 0, invalid
+	}
+
+	var values [2]float64
+	var hemispheres [2]byte
+	for i, p := range parts {
+		if values[i], hemispheres[i], err = parseDMSPart(p); err != nil {
+			return 0, 0, invalid
+		}
+	}
+
+	switch {
+	case hemispheres[0] == 'E' || hemispheres[0] == 'W':
+		if hemispheres[1] == 'E' || hemispheres[1] == 'W' {
+			return 0, 0, invalid
+		}
+		lon, lat = values[0], values[1]
+	case hemispheres[1] == 'N' || hemispheres[1] == 'S':
+		return 0, 0, invalid
+	default:
+		lat, lon = values[0], values[1]
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, invalid
+	}
+	return lat, lon, nil
+}
+
+type dmsToken struct {
+	number     string
+	hemisphere byte
+}
+
+// splitDMS breaks s into the tokens of its latitude and longitude.
+func splitDMS(s string) ([2][]dmsToken, error) {
+	var (
+		tokens []dmsToken
+		comma  = -1
+		parts  [2][]dmsToken
+	)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '-' || r == '+' || r == '.' || unicode.IsDigit(r):
+			j := i + 1
+			for j < len(s) && (s[j] == '.' || s[j] >= '0' && s[j] <= '9') {
+				j++
+			}
+			tokens = append(tokens, dmsToken{number: s[i:j]})
+			i = j
+			continue
+		case strings.ContainsRune("NSEWnsew", r):
+			tokens = append(tokens, dmsToken{hemisphere: byte(unicode.ToUpper(r))})
+		case r == ',' || r == ';':
+			if comma >= 0 {
+				return parts, fmt.Errorf("more than one separator")
+			}
+			comma = len(tokens)
+		case unicode.IsSpace(r) || strings.ContainsRune(`°º˚'"′″’”`, r) || r == 'd' || r == 'D':
+		default:
+			return parts, fmt.Errorf("unexpected %q", r)
+		}
+		i += size
+	}
+
+	var split = -1
+	switch {
+	case comma >= 0:
+		split = comma
+	case len(tokens) > 0 && tokens[0].hemisphere != 0:
+		// Hemisphere before each value: split at the second hemisphere.
+		for i := 1; i < len(tokens); i++ {
+			if tokens[i].hemisphere != 0 {
+				split = i
+				break
+			}
+		}
+	default:
+		// Hemisphere after each value or no hemispheres at all.
+		for i, t := range tokens {
+			if t.hemisphere != 0 {
+				split = i + 1
+				break
+			}
+		}
+		if split < 0 && len(tokens)%2 == 0 {
+			split = len(tokens) / 2
+		}
+	}
+	if split <= 0 || split >= len(tokens) {
+		return parts, fmt.Errorf("cannot split coordinates")
+	}
+	parts[0], parts[1] = tokens[:split], tokens[split:]
+	return parts, nil
+}
+
+// parseDMSPart converts the tokens of one coordinate to decimal degrees and its hemisphere letter, 0 if none.
+func parseDMSPart(tokens []dmsToken) (float64, byte, error) {
+	var (
+		numbers    []string
+		hemisphere byte
+	)
+	for i, t := range tokens {
+		if t.hemisphere == 0 {
+			numbers = append(numbers, t.number)
+			continue
+		}
+		if hemisphere != 0 || (i != 0 && i != len(tokens)-1) {
+			return 0, 0, fmt.Errorf("misplaced hemisphere")
+		}
+		hemisphere = t.hemisphere
+	}
+	if len(numbers) == 0 || len(numbers) > 3 {
+		return 0, 0, fmt.Errorf("expected degrees, minutes and seconds")
+	}
+
+	var (
+		value    float64
+		scale    = 1.0
+		negative bool
+	)
+	for i, n := range numbers {
+		if i == 0 && (n[0] == '-' || n[0] == '+') {
+			negative, n = n[0] == '-', n[1:]
+			if negative && hemisphere != 0 {
+				return 0, 0, fmt.Errorf("sign and hemisphere")
+			}
+		}
+		// Only the last field may have decimals.
+		if n == "" || strings.ContainsAny(n, "+-") || (i < len(numbers)-1 && strings.Contains(n, ".")) {
+			return 0, 0, fmt.Errorf("invalid number %q", n)
+		}
+		v, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		if i > 0 && v >= 60 {
+			return 0, 0, fmt.Errorf("minutes or seconds out of range")
+		}
+		value += v / scale
+		scale *= 60
+	}
+	if negative || hemisphere == 'S' || hemisphere == 'W' {
+		value = -value
+	}
+	return value, hemisphere, nil
+}
+
+// TimeZoneDMS returns the time zone at a coordinate pair in degrees, minutes and seconds, see ParseDMS.
+func (fc Collection) TimeZoneDMS(s string) (string, error) {
+	lat, lon, err := ParseDMS(s)
+	if err != nil {
+		return "", err
+	}
+	return fc.timeZone(lat, lon)
+}
@@ -0,0 +1,52 @@
+package tz
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseDMS(t *testing.T) {
+	var tests = []struct {
+		Input string
+		Lat   float64
+		Lon   float64
+	}{
+		{Input: `41°53'51"N 87°37'14"W`, Lat: 41.8975, Lon: -87.620556},
+		{Input: `41°53′51″N, 87°37′14″W`, Lat: 41.8975, Lon: -87.620556},
+		{Input: `87°37'14"W 41°53'51"N`, Lat: 41.8975, Lon: -87.620556},
+		{Input: "N41 53.85 W87 37.2333", Lat: 41.8975, Lon: -87.620555},
+		{Input: "41d 53' 51\" n 87d 37' 14\" w", Lat: 41.8975, Lon: -87.620556},
+		{Input: "33 52 04 S 151 12 36 E", Lat: -33.867778, Lon: 151.21},
+		{Input: "-33 52 04, 151 12 36", Lat: -33.867778, Lon: 151.21},
+		{Input: "41.8975, -87.6206", Lat: 41.8975, Lon: -87.6206},
+		{Input: "41.8975 -87.6206", Lat: 41.8975, Lon: -87.6206},
+	}
+	for _, test := range tests {
+		lat, lon, err := ParseDMS(test.Input)
+		if err != nil {
+			t.Errorf("%s: %v", test.Input, err)
+			continue
+		}
+		if math.Abs(lat-test.Lat) > 1e-6 || math.Abs(lon-test.Lon) > 1e-6 {
+			t.Errorf("%s: got %v %v, expected %v %v", test.Input, lat, lon, test.Lat, test.Lon)
+		}
+	}
+
+	for _, input := range []string{"", "41°53'51\"N", `41°60'00"N 87°37'14"W`, `41°53'51"N 87°37'14"N`,
+		`41°53'51"E 87°37'14"W`, `-41°53'51"N 87°37'14"W`, `91°00'00"N 87°37'14"W`, "41.5 53 51 N 87 37 14 W",
+		"41 53 51 87 37", "41 N 53 W 12", "41x 87"} {
+		if _, _, err := ParseDMS(input); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestTimeZoneDMS(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	if tz, err := fc.TimeZoneDMS(`40°30'N 109°30'W`); err != nil || tz != "America/Denver" {
+		t.Errorf("got %q %v, expected America/Denver", tz, err)
+	}
+	if _, err := fc.TimeZoneDMS(`40°30'N 107°W`); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
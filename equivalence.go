@@ -0,0 +1,80 @@
+package tz
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ZoneClass is a set of time zones that keep the same UTC offset as each other over a window of time.
+type ZoneClass struct {
+	// Representative is the member covering the largest area, a good default to show for the class.
+	Representative string
+	// Members are the time zones of the class sorted by name, the representative included.
+	Members []string
+}
+
+// EquivalentZones groups the time zones in the collection into classes whose members have the same UTC
+// offset at every instant from from up to but excluding to. America/Detroit and America/New_York fall in one
+// class from 1976 onward. Abbreviations are not compared. Classes are sorted by representative.
+func (fc Collection) EquivalentZones(from, to time.Time) ([]ZoneClass, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("end %v not after start %v", to, from)
+	}
+
+	var area = make(map[string]float64)
+	for _, f := range fc.Features {
+		if f != nil {
+			area[fc.featureTimeZone(f)] += f.Area()
+		}
+	}
+
+	var classes = make(map[string]*ZoneClass)
+	for tzid := range area {
+		loc, err := LoadLocation(tzid)
+		if err != nil {
+			return nil, err
+		}
+		key := offsetSignature(loc, from, to)
+		class, ok := classes[key]
+		if !ok {
+			class = &ZoneClass{}
+			classes[key] = class
+		}
+		class.Members = append(class.Members, tzid)
+	}
+
+	var result = make([]ZoneClass, 0, len(classes))
+	for _, class := range classes {
+		sort.Strings(class.Members)
+		class.Representative = class.Members[0]
+		for _, tzid := range class.Members[1:] {
+			if area[tzid] > area[class.Representative] {
+				class.Representative = tzid
+			}
+		}
+		result = append(result, *class)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Representative < result[j].Representative
+	})
+	return result, nil
+}
+
+// offsetSignature describes the offsets of loc in [from, to) as a string, equal for locations with the
+// same offset at every instant: the offset at from followed by each change of offset.
+func offsetSignature(loc *time.Location, from, to time.Time) string {
+	var (
+		_, offset = from.In(loc).Zone()
+		b         strings.Builder
+	)
+	b.WriteString(strconv.Itoa(offset))
+	for _, tr := range transitions(loc, from, to) {
+		if tr.OffsetAfter != tr.OffsetBefore {
+			fmt.Fprintf(&b, " %d:%d", tr.At.Unix(), tr.OffsetAfter)
+		}
+	}
+	return b.String()
+}
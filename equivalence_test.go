@@ -0,0 +1,74 @@
+package tz
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// equivalenceGeoJSON has Detroit and New York, which share rules since 1976, beside a larger Indianapolis,
+// which joined them in 2006, and Phoenix, which keeps no daylight saving time.
+const equivalenceGeoJSON = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "America/New_York"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-75, 40], [-73, 40], [-73, 42], [-75, 42], [-75, 40]]]}},
+	{"type": "Feature", "properties": {"tzid": "America/Detroit"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-84, 42], [-83, 42], [-83, 43], [-84, 43], [-84, 42]]]}},
+	{"type": "Feature", "properties": {"tzid": "America/Indiana/Indianapolis"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-88, 37], [-85, 37], [-85, 41], [-88, 41], [-88, 37]]]}},
+	{"type": "Feature", "properties": {"tzid": "America/Phoenix"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-114, 31], [-109, 31], [-109, 37], [-114, 37], [-114, 31]]]}}
+]}`
+
+func TestEquivalentZones(t *testing.T) {
+	var fc = newTestCollection(t, equivalenceGeoJSON)
+	var tests = []struct {
+		Name    string
+		From    time.Time
+		To      time.Time
+		Classes []ZoneClass
+	}{
+		{
+			Name: "since 2007",
+			From: time.Date(2007, time.January, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Classes: []ZoneClass{
+				{Representative: "America/Indiana/Indianapolis", Members: []string{"America/Detroit", "America/Indiana/Indianapolis", "America/New_York"}},
+				{Representative: "America/Phoenix", Members: []string{"America/Phoenix"}},
+			},
+		},
+		{
+			Name: "since 2000",
+			From: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Classes: []ZoneClass{
+				{Representative: "America/Indiana/Indianapolis", Members: []string{"America/Indiana/Indianapolis"}},
+				{Representative: "America/New_York", Members: []string{"America/Detroit", "America/New_York"}},
+				{Representative: "America/Phoenix", Members: []string{"America/Phoenix"}},
+			},
+		},
+		{
+			Name: "one winter day",
+			From: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Classes: []ZoneClass{
+				{Representative: "America/Indiana/Indianapolis", Members: []string{"America/Detroit", "America/Indiana/Indianapolis", "America/New_York"}},
+				{Representative: "America/Phoenix", Members: []string{"America/Phoenix"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		classes, err := fc.EquivalentZones(test.From, test.To)
+		if err != nil {
+			t.Errorf("%s: %v", test.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(classes, test.Classes) {
+			t.Errorf("%s: got %+v, expected %+v", test.Name, classes, test.Classes)
+		}
+	}
+
+	var now = time.Now()
+	if _, err := fc.EquivalentZones(now, now); err == nil {
+		t.Error("empty window: expected error")
+	}
+}
@@ -0,0 +1,137 @@
+package tz
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// tzidProperty is the feature property holding the IANA time zone name.
+const tzidProperty = "tzid"
+
+// Feature returns the feature for a time zone name, the reverse of TimeZone.
+// With WithCanonicalNames set, any alias of the zone matches, and when the data holds features under
+// several aliases of one zone the first of them is returned.
+func (fc Collection) Feature(tzid string) (*Feature, error) {
+	var name = tzid
+	if fc.canonicalNames {
+		name = CanonicalTimeZone(tzid)
+	}
+	for _, f := range fc.Features {
+		if f != nil && fc.featureTimeZone(f) == name {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to find feature for time zone %q", tzid)
+}
+
+// TimeZone returns the IANA time zone name of the feature.
+func (f *Feature) TimeZone() string {
+	return f.Properties[tzidProperty]
+}
+
+// featureTimeZone returns the time zone name of a feature as the collection reports it, the canonical
+// name with WithCanonicalNames set.
+func (fc Collection) featureTimeZone(f *Feature) string {
+	if fc.canonicalNames {
+		return CanonicalTimeZone(f.TimeZone())
+	}
+	return f.TimeZone()
+}
+
+// Contains reports whether the point lies inside one of the feature's polygons, testing every vertex.
+func (f *Feature) Contains(lat, lon float64) bool {
+	var ok bool
+	if lat, lon, ok = normalizeCoordinates(lat, lon); !ok || !f.Geometry.inBounds(lat, lon) {
+		return false
+	}
+	for _, c := range f.Geometry.Coordinates {
+		if c.inBounds(lat, lon) && c.contains(Point{Lon: lon, Lat: lat}, 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// Area returns the area of the feature on the sphere in square meters.
+func (f *Feature) Area() float64 {
+	return f.Geometry.Area()
+}
+
+// RepresentativePoint returns a point guaranteed to be inside the feature: the pole of
+// inaccessibility of its largest polygon.
+func (f *Feature) RepresentativePoint() Point {
+	var (
+		largest *Coordinates
+		area    = -1.0
+	)
+	for i := range f.Geometry.Coordinates {
+		if a := f.Geometry.Coordinates[i].Area(); a > area {
+			largest, area = &f.Geometry.Coordinates[i], a
+		}
+	}
+	if largest == nil {
+		return Point{}
+	}
+	return poleOfInaccessibility(*largest)
+}
+
+// MarshalJSON encodes the feature as a GeoJSON Feature.
+func (f Feature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type       string            `json:"type"`
+		Properties map[string]string `json:"properties"`
+		Geometry   Geometry          `json:"geometry"`
+	}{Type: "Feature", Properties: f.Properties, Geometry: f.Geometry})
+}
+
+// MarshalJSON encodes the geometry as a GeoJSON Polygon, or a MultiPolygon when it has several polygons.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	var rings = make([][][][]float64, len(g.Coordinates))
+	for i, c := range g.Coordinates {
+		rings[i] = [][][]float64{positions(c.Polygon)}
+		for _, hole := range c.Holes {
+			rings[i] = append(rings[i], positions(hole))
+		}
+	}
+	if len(rings) == 1 {
+		return json.Marshal(struct {
+			Type        string        `json:"type"`
+			Coordinates [][][]float64 `json:"coordinates"`
+		}{Type: "Polygon", Coordinates: rings[0]})
+	}
+	return json.Marshal(struct {
+		Type        string          `json:"type"`
+		Coordinates [][][][]float64 `json:"coordinates"`
+	}{Type: "MultiPolygon", Coordinates: rings})
+}
+
+// positions converts a ring to GeoJSON positions.
+func positions(ring []Point) [][]float64 {
+	var p = make([][]float64, len(ring))
+	for i, v := range ring {
+		p[i] = []float64{v.Lon, v.Lat}
+	}
+	return p
+}
+
+// MarshalJSON encodes the collection as a GeoJSON FeatureCollection.
+func (fc Collection) MarshalJSON() ([]byte, error) {
+	var features = make([]*Feature, 0, len(fc.Features))
+	for _, f := range fc.Features {
+		if f != nil {
+			features = append(features, f)
+		}
+	}
+	return json.Marshal(struct {
+		Type     string     `json:"type"`
+		Features []*Feature `json:"features"`
+	}{Type: "FeatureCollection", Features: features})
+}
+
+func (g Geometry) inBounds(lat, lon float64) bool {
+	return g.MinPoint.Lat <= lat && g.MinPoint.Lon <= lon && g.MaxPoint.Lat >= lat && g.MaxPoint.Lon >= lon
+}
+
+func (c Coordinates) inBounds(lat, lon float64) bool {
+	return c.MinPoint.Lat <= lat && c.MinPoint.Lon <= lon && c.MaxPoint.Lat >= lat && c.MaxPoint.Lon >= lon
+}
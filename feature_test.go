@@ -0,0 +1,170 @@
+package tz
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// featureGeoJSON has a U shaped zone whose centroid falls outside it and a zone split in two.
+const featureGeoJSON = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "America/Denver"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-110, 35], [-104, 35], [-104, 45], [-105, 45], [-105, 36], [-109, 36], [-109, 45], [-110, 45], [-110, 35]]]}},
+	{"type": "Feature", "properties": {"tzid": "Pacific/Fiji"}, "geometry": {"type": "MultiPolygon",
+		"coordinates": [[[[177, -19], [180, -19], [180, -16], [177, -16], [177, -19]]],
+			[[[-180, -17], [-179, -17], [-179, -16], [-180, -16], [-180, -17]]]]}}
+]}`
+
+// holedGeoJSON has a zone with a hole holding an enclave zone, centered where the outer ring's center is.
+const holedGeoJSON = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Africa/Johannesburg"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[20, -34], [32, -34], [32, -24], [20, -24], [20, -34]],
+			[[24, -31], [24, -27], [28, -27], [28, -31], [24, -31]]]}},
+	{"type": "Feature", "properties": {"tzid": "Africa/Maseru"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[24, -31], [28, -31], [28, -27], [24, -27], [24, -31]]]}}
+]}`
+
+func TestFeature(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+
+	f, err := fc.Feature("America/Denver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.TimeZone() != "America/Denver" {
+		t.Errorf("got feature %q", f.TimeZone())
+	}
+	if f.Geometry.MinPoint != (Point{Lon: -110, Lat: 35}) || f.Geometry.MaxPoint != (Point{Lon: -104, Lat: 45}) {
+		t.Errorf("got bounds %v %v", f.Geometry.MinPoint, f.Geometry.MaxPoint)
+	}
+	if _, err := fc.Feature("Mars/Olympus_Mons"); err == nil {
+		t.Error("expected error for unknown time zone")
+	}
+}
+
+func TestFeatureArea(t *testing.T) {
+	var world = Coordinates{Polygon: []Point{{-180, -90}, {180, -90}, {180, 90}, {-180, 90}, {-180, -90}}}
+	if a, want := world.Area(), 4*math.Pi*earthRadius*earthRadius; math.Abs(a-want)/want > 1e-9 {
+		t.Errorf("world area %v, expected %v", a, want)
+	}
+
+	// A one degree cell on the equator is about 111.2 km square.
+	var cell = Coordinates{Polygon: []Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}
+	if a := cell.Area() / 1e6; math.Abs(a-12363.7) > 1 {
+		t.Errorf("equator cell area %v km²", a)
+	}
+
+	var fc = newTestCollection(t, featureGeoJSON)
+	f, _ := fc.Feature("Pacific/Fiji")
+	if a, want := f.Area(), f.Geometry.Coordinates[0].Area()+f.Geometry.Coordinates[1].Area(); a != want || a == 0 {
+		t.Errorf("multipolygon area %v, expected %v", a, want)
+	}
+}
+
+func TestFeatureRepresentativePoint(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	for _, tzid := range []string{"America/Denver", "Pacific/Fiji"} {
+		f, _ := fc.Feature(tzid)
+		p := f.RepresentativePoint()
+		if !f.Contains(p.Lat, p.Lon) {
+			t.Errorf("%s: representative point %v is outside", tzid, p)
+		}
+		if got := fc.TimeZone(p.Lat, p.Lon); got != tzid {
+			t.Errorf("%s: representative point %v resolves to %q", tzid, p, got)
+		}
+	}
+
+	// The widest part of Fiji is the polygon west of the antimeridian.
+	f, _ := fc.Feature("Pacific/Fiji")
+	if p := f.RepresentativePoint(); math.Abs(p.Lon-178.5) > 0.01 || math.Abs(p.Lat+17.5) > 0.01 {
+		t.Errorf("got %v, expected the middle of the larger polygon", p)
+	}
+
+	// Too thin for the search to find a cell inside, so the scanline fallback has to.
+	var sliver = Coordinates{Polygon: []Point{{0, 0}, {10, 0}, {10, 1e-9}, {0, 1e-9}, {0, 0}}, MaxPoint: Point{10, 1e-9}}
+	p := poleOfInaccessibility(sliver)
+	if !sliver.contains(p, 1) {
+		t.Errorf("sliver point %v is outside", p)
+	}
+}
+
+func TestFeatureGeoJSON(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	for _, tzid := range []string{"America/Denver", "Pacific/Fiji"} {
+		f, _ := fc.Feature(tzid)
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded["type"] != "Feature" {
+			t.Errorf("%s: got type %v", tzid, decoded["type"])
+		}
+
+		var g Feature
+		if err := json.Unmarshal(b, &g); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*f, g) {
+			t.Errorf("%s: round trip changed the feature\n%+v\n%+v", tzid, *f, g)
+		}
+	}
+
+	b, err := json.Marshal(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := newTestCollection(t, string(b)); !reflect.DeepEqual(got, fc) {
+		t.Errorf("round trip changed the collection")
+	}
+}
+
+func TestFeatureHoles(t *testing.T) {
+	var (
+		fc          = newTestCollection(t, holedGeoJSON)
+		outer       = Coordinates{Polygon: []Point{{20, -34}, {32, -34}, {32, -24}, {20, -24}, {20, -34}}}
+		enclave, _  = fc.Feature("Africa/Maseru")
+		surround, _ = fc.Feature("Africa/Johannesburg")
+	)
+	if len(surround.Geometry.Coordinates[0].Holes) != 1 {
+		t.Fatalf("got holes %v", surround.Geometry.Coordinates[0].Holes)
+	}
+	if surround.Contains(-29, 26) || !surround.Contains(-25, 21) || !enclave.Contains(-29, 26) {
+		t.Error("expected the hole to belong to the enclave only")
+	}
+	for _, accuracy := range []Accuracy{AccuracyFast, AccuracyExact} {
+		fc.accuracy = accuracy
+		if got := fc.TimeZone(-29, 26); got != "Africa/Maseru" {
+			t.Errorf("accuracy %d: got %q in the hole, expected Africa/Maseru", accuracy, got)
+		}
+	}
+	if a, want := surround.Area(), outer.Area()-enclave.Area(); math.Abs(a-want) > want*1e-12 {
+		t.Errorf("got area %v, expected %v", a, want)
+	}
+
+	p := surround.RepresentativePoint()
+	if !surround.Contains(p.Lat, p.Lon) || fc.TimeZone(p.Lat, p.Lon) != "Africa/Johannesburg" {
+		t.Errorf("representative point %v is not in Africa/Johannesburg", p)
+	}
+	var ring = Coordinates{Polygon: []Point{{0, 0}, {10, 0}, {10, 1e-9}, {0, 1e-9}, {0, 0}},
+		Holes: [][]Point{{{4, 0}, {6, 0}, {6, 1e-9}, {4, 1e-9}, {4, 0}}}, MaxPoint: Point{10, 1e-9}}
+	if p := poleOfInaccessibility(ring); !ring.contains(p, 1) {
+		t.Errorf("sliver point %v is outside or in the hole", p)
+	}
+
+	b, err := json.Marshal(surround)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g Feature
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*surround, g) {
+		t.Errorf("round trip changed the feature\n%+v\n%+v", *surround, g)
+	}
+}
@@ -0,0 +1,40 @@
+package tz
+
+import (
+	"fmt"
+	"strings"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// DecodeGeohash returns the bounds of a geohash cell.
+func DecodeGeohash(hash string) (minPoint, maxPoint Point, err error) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if hash == "" {
+		return Point{}, Point{}, fmt.Errorf("empty geohash")
+	}
+
+	minPoint, maxPoint = Point{Lon: -180, Lat: -90}, Point{Lon: 180, Lat: 90}
+	var even = true
+	for i := 0; i < len(hash); i++ {
+		v := strings.IndexByte(geohashAlphabet, hash[i])
+		if v < 0 {
+			return Point{}, Point{}, fmt.Errorf("invalid geohash %q", hash)
+		}
+		// Bits alternate between longitude and latitude, starting with longitude.
+		for bit := 4; bit >= 0; bit-- {
+			min, max := &minPoint.Lat, &maxPoint.Lat
+			if even {
+				min, max = &minPoint.Lon, &maxPoint.Lon
+			}
+			mid := (*min + *max) / 2
+			if v>>uint(bit)&1 == 1 {
+				*min = mid
+			} else {
+				*max = mid
+			}
+			even = !even
+		}
+	}
+	return minPoint, maxPoint, nil
+}
@@ -0,0 +1,40 @@
+package tz
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecodeGeohash(t *testing.T) {
+	var tests = []struct {
+		Hash string
+		Min  Point
+		Max  Point
+	}{
+		{Hash: "ezs42", Min: Point{-5.625, 42.5830078125}, Max: Point{-5.5810546875, 42.626953125}},
+		{Hash: "EZS42", Min: Point{-5.625, 42.5830078125}, Max: Point{-5.5810546875, 42.626953125}},
+		{Hash: "0", Min: Point{-180, -90}, Max: Point{-135, -45}},
+		{Hash: "z", Min: Point{135, 45}, Max: Point{180, 90}},
+	}
+	for _, test := range tests {
+		min, max, err := DecodeGeohash(test.Hash)
+		if err != nil {
+			t.Errorf("%s: %v", test.Hash, err)
+			continue
+		}
+		if min != test.Min || max != test.Max {
+			t.Errorf("%s: got %v %v, expected %v %v", test.Hash, min, max, test.Min, test.Max)
+		}
+	}
+
+	min, max, _ := DecodeGeohash("dp3wjztvtsb")
+	if lat, lon := (min.Lat+max.Lat)/2, (min.Lon+max.Lon)/2; math.Abs(lat-41.8781) > 1e-4 || math.Abs(lon+87.6298) > 1e-4 {
+		t.Errorf("chicago: got %v %v", lat, lon)
+	}
+
+	for _, hash := range []string{"", "ezs4a", "ezs42i", "u4pruyd l"} {
+		if _, _, err := DecodeGeohash(hash); err == nil {
+			t.Errorf("%q: expected error", hash)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/catmullet/tz/geodb"
+	"github.com/catmullet/tz/internal/zoneinfo"
 	"math"
+	"os"
+	"path/filepath"
 	"sort"
 	"time"
 )
@@ -20,6 +23,49 @@ type GeoJsonLookup interface {
 
 type Collection struct {
 	Features []*Feature
+
+	nauticalFallback bool
+	canonicalNames   bool
+	accuracy         Accuracy
+	storage          geoStorage
+	filename         string
+}
+
+// Option configures the Collection built by NewTZ.
+type Option func(*Collection)
+
+// Accuracy selects how thoroughly TimeZone tests polygons.
+type Accuracy int
+
+const (
+	// AccuracyFast tests a sample of each polygon's vertices, first a sparse one and then a denser one.
+	AccuracyFast Accuracy = iota
+	// AccuracyExact tests every vertex of each polygon.
+	AccuracyExact
+)
+
+// WithAccuracy sets the polygon test used by TimeZone, AccuracyFast by default.
+func WithAccuracy(accuracy Accuracy) Option {
+	return func(fc *Collection) {
+		fc.accuracy = accuracy
+	}
+}
+
+// WithDataFile loads the time zone polygons from a file on disk instead of the embedded data.
+// The file is GeoJSON, snappy compressed when its name contains "snappy".
+func WithDataFile(path string) Option {
+	return func(fc *Collection) {
+		fc.storage = newLocalGeoStorage(os.DirFS(filepath.Dir(path)))
+		fc.filename = filepath.Base(path)
+	}
+}
+
+// WithNauticalFallback makes TimeZone return the nautical Etc/GMT zone for the longitude when no feature matches,
+// which allows lookups in open water when only land polygons are loaded.
+func WithNauticalFallback() Option {
+	return func(fc *Collection) {
+		fc.nauticalFallback = true
+	}
 }
 
 type Feature struct {
@@ -34,7 +80,9 @@ type Geometry struct {
 }
 
 type Coordinates struct {
-	Polygon  []Point
+	Polygon []Point
+	// Holes are the interior rings cut out of the polygon, such as an enclave.
+	Holes    [][]Point
 	MaxPoint Point
 	MinPoint Point
 }
@@ -44,28 +92,43 @@ type Point struct {
 	Lat float64
 }
 
-var polygonType struct {
-	Type       string
-	Geometries []*Geometry
-}
-
-var polygon struct {
-	Coordinates [][][]float64
-}
-
-var multiPolygon struct {
-	Coordinates [][][][]float64
+func NewTZ(opts ...Option) (GeoJsonLookup, error) {
+	fc, err := NewCollection(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return fc, nil
 }
 
-func NewTZ() (GeoJsonLookup, error) {
+// NewCollection loads the embedded time zone polygons like NewTZ, returning the concrete Collection
+// for callers that need more than the GeoJsonLookup methods.
+func NewCollection(opts ...Option) (*Collection, error) {
 	var (
 		fc = &Collection{Features: make([]*Feature, 500)}
 	)
 
-	if b, err := newLocalGeoStorage(geodb.GeoDbEmbedDirectory).LoadFile(timeZonesFilename, &fc); err != nil || len(b) == 0 {
+	for _, opt := range opts {
+		opt(fc)
+	}
+	if fc.storage == nil {
+		fc.storage, fc.filename = newLocalGeoStorage(geodb.GeoDbEmbedDirectory), timeZonesFilename
+	}
+
+	if b, err := fc.storage.LoadFile(fc.filename, &fc); err != nil || len(b) == 0 {
 		return nil, fmt.Errorf("failed to load file, %w", err)
 	}
+	if fc.canonicalNames {
+		if _, err := zoneinfo.Aliases(); err != nil {
+			return nil, fmt.Errorf("failed to load aliases, %w", err)
+		}
+	}
+
+	fc.sortFeatures()
 
+	return fc, nil
+}
+
+func (fc *Collection) sortFeatures() {
 	for i := range fc.Features {
 		f := fc.Features[i]
 		sort.SliceStable(f.Geometry.Coordinates, func(i, j int) bool {
@@ -76,61 +139,79 @@ func NewTZ() (GeoJsonLookup, error) {
 	sort.SliceStable(fc.Features, func(i, j int) bool {
 		return fc.Features[i].Geometry.MinPoint.Lon <= fc.Features[j].Geometry.MinPoint.Lon
 	})
-
-	return fc, nil
 }
 
+// UnmarshalJSON decodes a GeoJSON Polygon or MultiPolygon, other geometry types are left empty.
 func (g *Geometry) UnmarshalJSON(data []byte) (err error) {
-	if err := json.Unmarshal(data, &polygonType); err != nil {
-		return err
+	var geometry struct {
+		Type        string
+		Coordinates json.RawMessage
 	}
-
-	if g.MaxPoint.Lat == 0.0 && g.MaxPoint.Lon == 0.0 {
-		g.MaxPoint = Point{Lon: -180.0, Lat: -90.0}
-	}
-	if g.MinPoint.Lat == 0.0 && g.MinPoint.Lon == 0.0 {
-		g.MinPoint = Point{Lon: 180.0, Lat: 90.0}
+	if err := json.Unmarshal(data, &geometry); err != nil {
+		return err
 	}
 
-	switch polygonType.Type {
+	var polygons [][][][]float64
+	switch geometry.Type {
 	case "Polygon":
-		if err := json.Unmarshal(data, &polygon); err != nil {
-			return err
+		var polygon [][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &polygon); err != nil {
+			return fmt.Errorf("failed to parse polygon, %w", err)
 		}
-		coord := Coordinates{Polygon: make([]Point, len(polygon.Coordinates[0])), MaxPoint: Point{Lon: -180.0,
-			Lat: -90.0}, MinPoint: Point{Lon: 180.0, Lat: 90.0}}
-		for i, v := range polygon.Coordinates[0] {
-			lon := v[0]
-			lat := v[1]
-			coord.Polygon[i].Lon = lon
-			coord.Polygon[i].Lat = lat
-			updateMaxMin(&coord.MaxPoint, &coord.MinPoint, lat, lon)
-			updateMaxMin(&g.MaxPoint, &g.MinPoint, lat, lon)
-		}
-		g.Coordinates = append(g.Coordinates, coord)
-		return nil
+		polygons = [][][][]float64{polygon}
 	case "MultiPolygon":
-		if err := json.Unmarshal(data, &multiPolygon); err != nil {
-			return err
+		if err := json.Unmarshal(geometry.Coordinates, &polygons); err != nil {
+			return fmt.Errorf("failed to parse multipolygon, %w", err)
 		}
-		g.Coordinates = make([]Coordinates, len(multiPolygon.Coordinates))
-		for j, poly := range multiPolygon.Coordinates {
-			coord := Coordinates{Polygon: make([]Point, len(poly[0])), MaxPoint: Point{Lon: -180.0,
-				Lat: -90.0}, MinPoint: Point{Lon: 180.0, Lat: 90.0}}
-			for i, v := range poly[0] {
-				lon := v[0]
-				lat := v[1]
-				coord.Polygon[i].Lon = lon
-				coord.Polygon[i].Lat = lat
-				updateMaxMin(&coord.MaxPoint, &coord.MinPoint, lat, lon)
-				updateMaxMin(&g.MaxPoint, &g.MinPoint, lat, lon)
-			}
-			g.Coordinates[j] = coord
+		if len(polygons) == 0 {
+			return fmt.Errorf("multipolygon has no polygons")
 		}
-		return nil
 	default:
 		return nil
 	}
+
+	g.MaxPoint, g.MinPoint = Point{Lon: -180.0, Lat: -90.0}, Point{Lon: 180.0, Lat: 90.0}
+	g.Coordinates = make([]Coordinates, len(polygons))
+	for j, poly := range polygons {
+		if len(poly) == 0 {
+			return fmt.Errorf("polygon %d has no rings", j)
+		}
+		ring, err := parseRing(poly[0])
+		if err != nil {
+			return fmt.Errorf("polygon %d, %w", j, err)
+		}
+		var holes [][]Point
+		for k, positions := range poly[1:] {
+			hole, err := parseRing(positions)
+			if err != nil {
+				return fmt.Errorf("polygon %d hole %d, %w", j, k, err)
+			}
+			holes = append(holes, hole)
+		}
+		coord := Coordinates{Polygon: ring, Holes: holes, MaxPoint: Point{Lon: -180.0, Lat: -90.0}, MinPoint: Point{Lon: 180.0,
+			Lat: 90.0}}
+		for _, p := range ring {
+			updateMaxMin(&coord.MaxPoint, &coord.MinPoint, p.Lat, p.Lon)
+			updateMaxMin(&g.MaxPoint, &g.MinPoint, p.Lat, p.Lon)
+		}
+		g.Coordinates[j] = coord
+	}
+	return nil
+}
+
+// parseRing converts GeoJSON positions to points, requiring at least three positions of longitude and latitude.
+func parseRing(positions [][]float64) ([]Point, error) {
+	if len(positions) < 3 {
+		return nil, fmt.Errorf("ring has %d positions, expected at least 3", len(positions))
+	}
+	var ring = make([]Point, len(positions))
+	for i, v := range positions {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("position %d has %d values, expected longitude and latitude", i, len(v))
+		}
+		ring[i] = Point{Lon: v[0], Lat: v[1]}
+	}
+	return ring, nil
 }
 
 func updateMaxMin(maxPoint, minPoint *Point, lat, lon float64) {
@@ -150,45 +231,118 @@ func updateMaxMin(maxPoint, minPoint *Point, lat, lon float64) {
 
 func (fc Collection) Location(lat, lon float64) (*time.Location, error) {
 	if tz := fc.TimeZone(lat, lon); tz != "" {
-		return time.LoadLocation(tz)
+		return LoadLocation(tz)
 	}
 	return nil, fmt.Errorf("failed to find time zone")
 }
 
+// timeZone is TimeZone with an error when no zone matches, for the lookups that decode their input first.
+func (fc Collection) timeZone(lat, lon float64) (string, error) {
+	if tz := fc.TimeZone(lat, lon); tz != "" {
+		return tz, nil
+	}
+	return "", fmt.Errorf("failed to find time zone")
+}
+
 // TimeZone Recurse over lower find function for lat lon.
 // First shrinking the polygon for search and if we find it return it. If we didn't find it search on full polygon.
+// With WithNauticalFallback set, points outside every polygon resolve to their nautical zone.
+// Latitudes outside [-90, 90] return an empty string and the poles resolve as described on NorthPoleTimeZone.
+// With WithCanonicalNames set, aliases in the data are returned as their canonical names.
 func (fc Collection) TimeZone(lat, lon float64) string {
-	var start, end = 0.001, 0.0001
-	if result := fc.find(lat, lon, start); result != "" {
-		return result
-	}
-	return fc.find(lat, lon, end)
-}
-
-func (fc Collection) find(lat, lon, percentage float64) string {
-	for _, feat := range fc.Features {
-		f := feat
-		properties := f.Properties
-		if f.Geometry.MinPoint.Lat <= lat &&
-			f.Geometry.MinPoint.Lon <= lon &&
-			f.Geometry.MaxPoint.Lat >= lat &&
-			f.Geometry.MaxPoint.Lon >= lon {
-			for _, c := range f.Geometry.Coordinates {
-				coord := c
-				if coord.MinPoint.Lat <= lat &&
-					coord.MinPoint.Lon <= lon &&
-					coord.MaxPoint.Lat >= lat &&
-					coord.MaxPoint.Lon >= lon {
-					if coord.contains(Point{lon, lat},
-						// get a percentage of the polygon, either shrinking it or leaving it alone.
-						int(math.Max(float64(len(coord.Polygon))*percentage, 1))) {
-						return properties["tzid"]
-					}
+	tz, _ := fc.FeatureAt(lat, lon)
+	return tz
+}
+
+// FeatureAt returns the time zone at a point like TimeZone, together with the feature it was found in.
+// The feature is nil when no feature matched, at the poles and for nautical fallback zones.
+func (fc Collection) FeatureAt(lat, lon float64) (string, *Feature) {
+	var tz, f = fc.lookup(lat, lon)
+	if fc.canonicalNames && tz != "" {
+		return CanonicalTimeZone(tz), f
+	}
+	return tz, f
+}
+
+// FeatureContains reports whether the feature contains the point by the same polygon test TimeZone uses,
+// so a feature returned by FeatureAt can be tested against the next point of a track without a full lookup.
+// It reports false at the poles, which TimeZone resolves without testing polygons.
+func (fc Collection) FeatureContains(f *Feature, lat, lon float64) bool {
+	var ok bool
+	if lat, lon, ok = normalizeCoordinates(lat, lon); !ok {
+		return false
+	}
+	if _, ok := poleTimeZone(lat); ok {
+		return false
+	}
+	for _, percentage := range fc.samplings() {
+		if f.sampledContains(lat, lon, percentage) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fc Collection) lookup(lat, lon float64) (string, *Feature) {
+	var ok bool
+	if lat, lon, ok = normalizeCoordinates(lat, lon); !ok {
+		return "", nil
+	}
+	if tz, ok := poleTimeZone(lat); ok {
+		return tz, nil
+	}
+
+	for _, percentage := range fc.samplings() {
+		if f := fc.find(lat, lon, percentage); f != nil {
+			return f.Properties["tzid"], f
+		}
+	}
+	if !fc.nauticalFallback {
+		return "", nil
+	}
+	return NauticalTimeZone(lon), nil
+}
+
+// samplings returns the share of each polygon's vertices tested by each pass of a lookup, a sparse
+// sample and then a denser one. The exact search tests every vertex in a single pass, since a second
+// pass would only repeat it.
+func (fc Collection) samplings() []float64 {
+	if fc.accuracy == AccuracyExact {
+		return []float64{0}
+	}
+	return []float64{0.001, 0.0001}
+}
+
+func (fc Collection) find(lat, lon, percentage float64) *Feature {
+	for _, f := range fc.Features {
+		if f.sampledContains(lat, lon, percentage) {
+			return f
+		}
+	}
+	return nil
+}
+
+// sampledContains tests the point against a share of the vertices of each of the feature's polygons.
+func (f *Feature) sampledContains(lat, lon, percentage float64) bool {
+	if f.Geometry.MinPoint.Lat <= lat &&
+		f.Geometry.MinPoint.Lon <= lon &&
+		f.Geometry.MaxPoint.Lat >= lat &&
+		f.Geometry.MaxPoint.Lon >= lon {
+		for _, c := range f.Geometry.Coordinates {
+			coord := c
+			if coord.MinPoint.Lat <= lat &&
+				coord.MinPoint.Lon <= lon &&
+				coord.MaxPoint.Lat >= lat &&
+				coord.MaxPoint.Lon >= lon {
+				if coord.contains(Point{lon, lat},
+					// get a percentage of the polygon, either shrinking it or leaving it alone.
+					int(math.Max(float64(len(coord.Polygon))*percentage, 1))) {
+					return true
 				}
 			}
 		}
 	}
-	return ""
+	return false
 }
 
 func (c Coordinates) contains(point Point, indexjump int) bool {
@@ -196,6 +350,12 @@ func (c Coordinates) contains(point Point, indexjump int) bool {
 	if windingNumber(point.Lat, point.Lon, polygon, indexjump) == 0 {
 		return false
 	}
+	// Holes are small next to the outer ring, so they are always tested exactly.
+	for _, hole := range c.Holes {
+		if windingNumber(point.Lat, point.Lon, hole, 1) != 0 {
+			return false
+		}
+	}
 	return true
 }
 
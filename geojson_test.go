@@ -1,9 +1,12 @@
 package tz
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/golang/snappy"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -91,6 +94,17 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// newTestCollection builds a Collection from a GeoJSON FeatureCollection the same way NewTZ does.
+func newTestCollection(t testing.TB, geojson string) *Collection {
+	t.Helper()
+	var fc = &Collection{}
+	if err := json.Unmarshal([]byte(geojson), fc); err != nil {
+		t.Fatal(err)
+	}
+	fc.sortFeatures()
+	return fc
+}
+
 func TestSmallPolygon(t *testing.T) {
 	tz := tzl.TimeZone(24.3000, 153.9667)
 	t.Log("time_zone:", tz)
@@ -99,6 +113,33 @@ func TestSmallPolygon(t *testing.T) {
 	}
 }
 
+func TestWithDataFile(t *testing.T) {
+	var dir = t.TempDir()
+	var files = map[string][]byte{
+		"zones.json":        []byte(featureGeoJSON),
+		"zones.json.snappy": snappy.Encode(nil, []byte(featureGeoJSON)),
+	}
+	for name, b := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, accuracy := range []Accuracy{AccuracyFast, AccuracyExact} {
+			fc, err := NewCollection(WithDataFile(path), WithAccuracy(accuracy))
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if tz := fc.TimeZone(40, -109.5); tz != "America/Denver" {
+				t.Errorf("%s: got %q", name, tz)
+			}
+		}
+	}
+
+	if _, err := NewCollection(WithDataFile(filepath.Join(dir, "missing.json"))); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
 func BenchmarkLongTimeZone(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		tzl.TimeZone(5.840370, -55.196100)
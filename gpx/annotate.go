@@ -0,0 +1,69 @@
+package gpx
+
+import (
+	"github.com/catmullet/tz"
+	"time"
+)
+
+// AnnotatedPoint is a track point tagged with its time zone. Local is the point's time in that zone,
+// zero when the point has no time or lies outside every zone.
+type AnnotatedPoint struct {
+	Track   int
+	Segment int
+	Point
+	TZID  string
+	Local time.Time
+}
+
+// ZoneChange records where a track moves from one time zone to another. Index is the position in
+// Annotation.Points of the first point in the new zone.
+type ZoneChange struct {
+	Index int
+	From  string
+	To    string
+	Time  time.Time
+	Lat   float64
+	Lon   float64
+}
+
+// Annotation is the result of tagging every point of a GPX document.
+type Annotation struct {
+	Points []AnnotatedPoint
+	// Changes lists every change of zone between consecutive points, across segments and tracks.
+	Changes []ZoneChange
+	// Zones lists the zones visited in the order they were first entered.
+	Zones []string
+}
+
+// Annotate looks up the time zone of every track point, in document order.
+func Annotate(lookup tz.TimeZoneLookup, g *GPX) (*Annotation, error) {
+	var (
+		a    = &Annotation{}
+		seen = make(map[string]bool)
+	)
+	for i, trk := range g.Tracks {
+		for j, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				ap := AnnotatedPoint{Track: i, Segment: j, Point: p, TZID: lookup.TimeZone(p.Lat, p.Lon)}
+				if ap.TZID != "" && !p.Time.IsZero() {
+					loc, err := tz.LoadLocation(ap.TZID)
+					if err != nil {
+						return nil, err
+					}
+					ap.Local = p.Time.In(loc)
+				}
+
+				if n := len(a.Points); n > 0 && a.Points[n-1].TZID != ap.TZID {
+					a.Changes = append(a.Changes, ZoneChange{Index: n, From: a.Points[n-1].TZID, To: ap.TZID,
+						Time: p.Time, Lat: p.Lat, Lon: p.Lon})
+				}
+				if ap.TZID != "" && !seen[ap.TZID] {
+					seen[ap.TZID] = true
+					a.Zones = append(a.Zones, ap.TZID)
+				}
+				a.Points = append(a.Points, ap)
+			}
+		}
+	}
+	return a, nil
+}
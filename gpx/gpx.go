@@ -0,0 +1,172 @@
+// Package gpx tags the points of GPX 1.1 tracks with their time zone and local wall-clock time.
+package gpx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Namespace is the XML namespace of the extension elements written by WriteGPX.
+const Namespace = "https://github.com/catmullet/tz/gpx/v1"
+
+// GPX holds the tracks of a GPX document. Waypoints, routes, extensions and the other elements the
+// package does not interpret are kept as read, so that WriteGPX can write them back.
+type GPX struct {
+	Creator string
+	Tracks  []Track
+
+	kept
+	// attrs are the attributes of the gpx element other than its version and creator, such as the
+	// namespace declarations the kept elements rely on.
+	attrs []xml.Attr
+	// metadata is nil when the document has no metadata element.
+	metadata *kept
+}
+
+// Track is a named list of segments.
+type Track struct {
+	Name     string
+	Segments []Segment
+
+	kept
+}
+
+// Segment is a run of points recorded without interruption.
+type Segment struct {
+	Points []Point
+
+	kept
+}
+
+// Point is a track point. Time is zero when the point has none.
+type Point struct {
+	Lat  float64
+	Lon  float64
+	Ele  *float64
+	Time time.Time
+
+	kept
+}
+
+// kept holds the children of an element that the package does not interpret.
+type kept struct {
+	other []element
+	// extensions has the elements in Namespace left by an earlier WriteGPX removed, nil when the
+	// element has no extensions.
+	extensions *xmlExtensions
+}
+
+// element is an XML element kept as read.
+type element struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+type xmlGPX struct {
+	Creator  string     `xml:"creator,attr"`
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Metadata *xmlKept   `xml:"metadata"`
+	Tracks   []xmlTrack `xml:"trk"`
+	xmlKept
+}
+
+type xmlTrack struct {
+	Name     string       `xml:"name"`
+	Segments []xmlSegment `xml:"trkseg"`
+	xmlKept
+}
+
+type xmlSegment struct {
+	Points []xmlPoint `xml:"trkpt"`
+	xmlKept
+}
+
+type xmlPoint struct {
+	Lat  float64  `xml:"lat,attr"`
+	Lon  float64  `xml:"lon,attr"`
+	Ele  *float64 `xml:"ele"`
+	Time string   `xml:"time"`
+	xmlKept
+}
+
+type xmlKept struct {
+	Extensions *xmlExtensions `xml:"extensions"`
+	Other      []element      `xml:",any"`
+}
+
+type xmlExtensions struct {
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []element  `xml:",any"`
+}
+
+func (x xmlKept) kept() kept {
+	var k = kept{other: x.Other}
+	if x.Extensions != nil {
+		k.extensions = &xmlExtensions{Attrs: x.Extensions.Attrs}
+		for _, e := range x.Extensions.Children {
+			if e.XMLName.Space != Namespace {
+				k.extensions.Children = append(k.extensions.Children, e)
+			}
+		}
+	}
+	return k
+}
+
+// timeLayouts are the xsd:dateTime forms accepted for point times. Times without a zone are taken as UTC.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"}
+
+// Parse reads the tracks of a GPX 1.1 document.
+func Parse(r io.Reader) (*GPX, error) {
+	var doc xmlGPX
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse gpx: %w", err)
+	}
+
+	var g = &GPX{Creator: doc.Creator, Tracks: make([]Track, len(doc.Tracks)), kept: doc.kept()}
+	for _, attr := range doc.Attrs {
+		switch attr.Name {
+		case xml.Name{Local: "version"}, xml.Name{Space: "xmlns", Local: "tz"}:
+			continue
+		}
+		g.attrs = append(g.attrs, attr)
+	}
+	if doc.Metadata != nil {
+		metadata := doc.Metadata.kept()
+		g.metadata = &metadata
+	}
+	for i, trk := range doc.Tracks {
+		g.Tracks[i] = Track{Name: trk.Name, Segments: make([]Segment, len(trk.Segments)), kept: trk.kept()}
+		for j, seg := range trk.Segments {
+			g.Tracks[i].Segments[j].kept = seg.kept()
+			points := make([]Point, len(seg.Points))
+			for k, p := range seg.Points {
+				points[k] = Point{Lat: p.Lat, Lon: p.Lon, Ele: p.Ele, kept: p.kept()}
+				if p.Time == "" {
+					continue
+				}
+				t, err := parseTime(strings.TrimSpace(p.Time))
+				if err != nil {
+					return nil, fmt.Errorf("track %d segment %d point %d: %w", i, j, k, err)
+				}
+				points[k].Time = t
+			}
+			g.Tracks[i].Segments[j].Points = points
+		}
+	}
+	return g, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
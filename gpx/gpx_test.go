@@ -0,0 +1,233 @@
+package gpx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeLookup puts everything west of -87 in Chicago, everything from -87 to -80 in Detroit and
+// leaves the rest without a zone.
+type fakeLookup struct{}
+
+func (fakeLookup) TimeZone(lat, lon float64) string {
+	switch {
+	case lon < -87:
+		return "America/Chicago"
+	case lon < -80:
+		return "America/Detroit"
+	default:
+		return ""
+	}
+}
+
+func (f fakeLookup) Location(lat, lon float64) (*time.Location, error) {
+	return time.LoadLocation(f.TimeZone(lat, lon))
+}
+
+const track = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="field &amp; co" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <name>Lake Michigan loop</name>
+    <trkseg>
+      <trkpt lat="41.88" lon="-87.63"><ele>181.5</ele><time>2026-03-08T07:59:00Z</time></trkpt>
+      <trkpt lat="41.90" lon="-86.90"><time>2026-03-08T08:30:00.5Z</time></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="42.00" lon="-79.00"><time>2026-03-08T09:00:00</time></trkpt>
+      <trkpt lat="42.10" lon="-88.00"></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestAnnotate(t *testing.T) {
+	g, err := Parse(strings.NewReader(track))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Creator != "field & co" || len(g.Tracks) != 1 || len(g.Tracks[0].Segments) != 2 {
+		t.Fatalf("got %+v", g)
+	}
+	if ele := g.Tracks[0].Segments[0].Points[0].Ele; ele == nil || *ele != 181.5 {
+		t.Errorf("got elevation %v", ele)
+	}
+
+	a, err := Annotate(fakeLookup{}, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want = []struct {
+		TZID  string
+		Local string
+	}{
+		{TZID: "America/Chicago", Local: "2026-03-08T01:59:00-06:00"},
+		{TZID: "America/Detroit", Local: "2026-03-08T04:30:00.5-04:00"},
+		{TZID: "", Local: ""},
+		{TZID: "America/Chicago", Local: ""},
+	}
+	if len(a.Points) != len(want) {
+		t.Fatalf("got %d points", len(a.Points))
+	}
+	for i, p := range a.Points {
+		var local string
+		if !p.Local.IsZero() {
+			local = p.Local.Format(time.RFC3339Nano)
+		}
+		if p.TZID != want[i].TZID || local != want[i].Local {
+			t.Errorf("point %d: got %q %q, expected %q %q", i, p.TZID, local, want[i].TZID, want[i].Local)
+		}
+	}
+	if a.Points[2].Segment != 1 {
+		t.Errorf("got segment %d", a.Points[2].Segment)
+	}
+
+	if zones := strings.Join(a.Zones, ","); zones != "America/Chicago,America/Detroit" {
+		t.Errorf("got zones %s", zones)
+	}
+	var changes []string
+	for _, c := range a.Changes {
+		changes = append(changes, c.From+">"+c.To)
+	}
+	if got := strings.Join(changes, ","); got != "America/Chicago>America/Detroit,America/Detroit>,>America/Chicago" {
+		t.Errorf("got changes %s", got)
+	}
+	if a.Changes[0].Index != 1 || !a.Changes[0].Time.Equal(time.Date(2026, 3, 8, 8, 30, 0, 5e8, time.UTC)) {
+		t.Errorf("got first change %+v", a.Changes[0])
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g, err := Parse(strings.NewReader(track))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := Annotate(fakeLookup{}, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var csv bytes.Buffer
+	if err := a.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 5 || lines[1] != "0,0,41.88,-87.63,181.5,2026-03-08T07:59:00Z,America/Chicago,2026-03-08T01:59:00-06:00" {
+		t.Errorf("got csv\n%s", csv.String())
+	}
+
+	var out bytes.Buffer
+	if err := a.WriteGPX(&out, g); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`creator="field &amp; co"`,
+		`<tz:change index="1" from="America/Chicago" to="America/Detroit" time="2026-03-08T08:30:00.5Z"/>`,
+		`<tz:tzid>America/Detroit</tz:tzid>`,
+		`<tz:localtime>2026-03-08T04:30:00.5-04:00</tz:localtime>`,
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("output is missing %s\n%s", s, out.String())
+		}
+	}
+
+	// The output is GPX itself and parses back to the same points.
+	again, err := Parse(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Annotate(fakeLookup{}, again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range a.Points {
+		if !a.Points[i].Time.Equal(b.Points[i].Time) || a.Points[i].TZID != b.Points[i].TZID {
+			t.Errorf("point %d changed: %+v, %+v", i, a.Points[i], b.Points[i])
+		}
+	}
+
+	var summary bytes.Buffer
+	if err := a.WriteSummary(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if want := "4 points in 2 zones: America/Chicago, America/Detroit\n" +
+		"2026-03-08T08:30:00Z point 1 at 41.9,-86.9: America/Chicago -> America/Detroit\n" +
+		"2026-03-08T09:00:00Z point 2 at 42,-79: America/Detroit -> no zone\n" +
+		"point 3 at 42.1,-88: no zone -> America/Chicago\n"; summary.String() != want {
+		t.Errorf("got summary\n%s", summary.String())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, doc := range []string{
+		`<gpx><trk><trkseg><trkpt lat="1" lon="2"><time>yesterday</time></trkpt></trkseg></trk></gpx>`,
+		`<gpx><trk>`,
+	} {
+		if _, err := Parse(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: expected error", doc)
+		}
+	}
+}
+
+const extendedTrack = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="watch" xmlns="http://www.topografix.com/GPX/1/1"
+  xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1"
+  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+  xsi:schemaLocation="http://www.topografix.com/GPX/1/1 http://www.topografix.com/GPX/1/1/gpx.xsd">
+  <metadata><name>Morning run</name><extensions><x:device xmlns:x="urn:device">watch</x:device></extensions></metadata>
+  <wpt lat="41.89" lon="-87.62"><name>Start &amp; finish</name></wpt>
+  <rte><name>Planned</name><rtept lat="41.9" lon="-87.6"/></rte>
+  <trk>
+    <name>Run</name>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="41.88" lon="-87.63">
+        <time>2026-03-08T07:59:00Z</time>
+        <sat>7</sat>
+        <extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>142</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions>
+      </trkpt>
+    </trkseg>
+  </trk>
+  <extensions><lap xmlns="urn:laps">1</lap></extensions>
+</gpx>`
+
+func TestWriteKeepsElements(t *testing.T) {
+	var write = func(doc string) string {
+		g, err := Parse(strings.NewReader(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, err := Annotate(fakeLookup{}, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := a.WriteGPX(&out, g); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	var out = write(extendedTrack)
+	for _, s := range []string{
+		`xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1"`,
+		`xsi:schemaLocation="http://www.topografix.com/GPX/1/1 http://www.topografix.com/GPX/1/1/gpx.xsd"`,
+		"    <name>Morning run</name>\n    <extensions>\n      <x:device xmlns:x=\"urn:device\">watch</x:device>\n",
+		`<wpt lat="41.89" lon="-87.62"><name>Start &amp; finish</name></wpt>`,
+		`<rte><name>Planned</name><rtept lat="41.9" lon="-87.6"/></rte>`,
+		"    <name>Run</name>\n    <type>running</type>\n    <trkseg>\n",
+		"        <sat>7</sat>\n        <extensions>\n" +
+			"          <gpxtpx:TrackPointExtension><gpxtpx:hr>142</gpxtpx:hr></gpxtpx:TrackPointExtension>\n" +
+			"          <tz:tzid>America/Chicago</tz:tzid>\n",
+		"  <extensions>\n    <lap xmlns=\"urn:laps\">1</lap>\n  </extensions>\n</gpx>\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("output is missing %s\n%s", s, out)
+		}
+	}
+
+	// Writing the output again replaces the zone elements instead of adding a second set.
+	if again := write(out); again != out {
+		t.Errorf("got\n%s\nexpected\n%s", again, out)
+	}
+}
@@ -0,0 +1,253 @@
+package gpx
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteCSV writes one row per annotated point.
+func (a *Annotation) WriteCSV(w io.Writer) error {
+	var cw = csv.NewWriter(w)
+	if err := cw.Write([]string{"track", "segment", "lat", "lon", "ele", "time", "tzid", "local_time"}); err != nil {
+		return err
+	}
+	for _, p := range a.Points {
+		var ele, utc, local string
+		if p.Ele != nil {
+			ele = strconv.FormatFloat(*p.Ele, 'f', -1, 64)
+		}
+		if !p.Time.IsZero() {
+			utc = p.Time.Format(time.RFC3339Nano)
+		}
+		if !p.Local.IsZero() {
+			local = p.Local.Format(time.RFC3339Nano)
+		}
+		if err := cw.Write([]string{strconv.Itoa(p.Track), strconv.Itoa(p.Segment),
+			strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Lon, 'f', -1, 64),
+			ele, utc, p.TZID, local}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSummary writes the zones visited and one line per change of zone.
+func (a *Annotation) WriteSummary(w io.Writer) error {
+	var bw = bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%d points in %d zones: %s\n", len(a.Points), len(a.Zones), strings.Join(a.Zones, ", "))
+	for _, c := range a.Changes {
+		from, to := c.From, c.To
+		if from == "" {
+			from = "no zone"
+		}
+		if to == "" {
+			to = "no zone"
+		}
+		var at string
+		if !c.Time.IsZero() {
+			at = c.Time.Format(time.RFC3339) + " "
+		}
+		fmt.Fprintf(bw, "%spoint %d at %v,%v: %s -> %s\n", at, c.Index, c.Lat, c.Lon, from, to)
+	}
+	return bw.Flush()
+}
+
+// gpxNamespace is the namespace of GPX 1.1, the default namespace of the documents written by WriteGPX.
+const gpxNamespace = "http://www.topografix.com/GPX/1/1"
+
+// WriteGPX writes the annotated tracks as GPX 1.1, with each point's tzid and local time in
+// extension elements of Namespace and the changes of zone in the metadata extensions.
+// The waypoints, routes, extensions and other elements kept by Parse are written back unchanged,
+// except that elements in Namespace from an earlier WriteGPX are replaced.
+func (a *Annotation) WriteGPX(w io.Writer, g *GPX) error {
+	var (
+		bw       = bufio.NewWriter(w)
+		prefixes = namespacePrefixes(map[string]string{gpxNamespace: ""}, g.attrs)
+	)
+	fmt.Fprintf(bw, "%s<gpx version=\"1.1\" creator=\"%s\" xmlns=\"%s\" xmlns:tz=\"%s\"",
+		xml.Header, escape(g.Creator), gpxNamespace, Namespace)
+	for _, attr := range g.attrs {
+		if attr.Name != (xml.Name{Local: "xmlns"}) {
+			writeAttr(bw, attr, prefixes)
+		}
+	}
+	bw.WriteString(">\n")
+
+	if g.metadata != nil || len(a.Changes) > 0 {
+		var changes strings.Builder
+		for _, c := range a.Changes {
+			fmt.Fprintf(&changes, "      <tz:change index=\"%d\" from=\"%s\" to=\"%s\"", c.Index, escape(c.From), escape(c.To))
+			if !c.Time.IsZero() {
+				fmt.Fprintf(&changes, " time=\"%s\"", c.Time.UTC().Format(time.RFC3339Nano))
+			}
+			changes.WriteString("/>\n")
+		}
+		var metadata kept
+		if g.metadata != nil {
+			metadata = *g.metadata
+		}
+		bw.WriteString("  <metadata>\n")
+		metadata.writeOther(bw, "    ", prefixes)
+		metadata.writeExtensions(bw, "    ", prefixes, changes.String())
+		bw.WriteString("  </metadata>\n")
+	}
+	g.writeOther(bw, "  ", prefixes)
+
+	var i int
+	for _, trk := range g.Tracks {
+		bw.WriteString("  <trk>\n")
+		if trk.Name != "" {
+			fmt.Fprintf(bw, "    <name>%s</name>\n", escape(trk.Name))
+		}
+		trk.writeOther(bw, "    ", prefixes)
+		trk.writeExtensions(bw, "    ", prefixes, "")
+		for _, seg := range trk.Segments {
+			bw.WriteString("    <trkseg>\n")
+			for range seg.Points {
+				if i >= len(a.Points) {
+					return fmt.Errorf("annotation has %d points, fewer than the gpx", len(a.Points))
+				}
+				writePoint(bw, a.Points[i], prefixes)
+				i++
+			}
+			seg.writeOther(bw, "      ", prefixes)
+			seg.writeExtensions(bw, "      ", prefixes, "")
+			bw.WriteString("    </trkseg>\n")
+		}
+		bw.WriteString("  </trk>\n")
+	}
+	g.writeExtensions(bw, "  ", prefixes, "")
+	bw.WriteString("</gpx>\n")
+	return bw.Flush()
+}
+
+func writePoint(bw *bufio.Writer, p AnnotatedPoint, prefixes map[string]string) {
+	fmt.Fprintf(bw, "      <trkpt lat=\"%s\" lon=\"%s\">\n",
+		strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Lon, 'f', -1, 64))
+	if p.Ele != nil {
+		fmt.Fprintf(bw, "        <ele>%s</ele>\n", strconv.FormatFloat(*p.Ele, 'f', -1, 64))
+	}
+	if !p.Time.IsZero() {
+		fmt.Fprintf(bw, "        <time>%s</time>\n", p.Time.UTC().Format(time.RFC3339Nano))
+	}
+	p.writeOther(bw, "        ", prefixes)
+	var zone string
+	if p.TZID != "" {
+		zone = fmt.Sprintf("          <tz:tzid>%s</tz:tzid>\n", escape(p.TZID))
+		if !p.Local.IsZero() {
+			zone += fmt.Sprintf("          <tz:localtime>%s</tz:localtime>\n", p.Local.Format(time.RFC3339Nano))
+		}
+	}
+	p.writeExtensions(bw, "        ", prefixes, zone)
+	bw.WriteString("      </trkpt>\n")
+}
+
+// writeOther writes the kept child elements, one per line.
+func (k kept) writeOther(bw *bufio.Writer, indent string, prefixes map[string]string) {
+	for _, e := range k.other {
+		bw.WriteString(indent)
+		e.write(bw, prefixes)
+		bw.WriteByte('\n')
+	}
+}
+
+// writeExtensions writes the kept extensions followed by own, the lines of this package's elements.
+// Nothing is written when there are neither.
+func (k kept) writeExtensions(bw *bufio.Writer, indent string, prefixes map[string]string, own string) {
+	if k.extensions == nil && own == "" {
+		return
+	}
+	bw.WriteString(indent + "<extensions")
+	if k.extensions != nil {
+		prefixes = namespacePrefixes(prefixes, k.extensions.Attrs)
+		for _, attr := range k.extensions.Attrs {
+			writeAttr(bw, attr, prefixes)
+		}
+	}
+	bw.WriteString(">\n")
+	if k.extensions != nil {
+		for _, e := range k.extensions.Children {
+			bw.WriteString(indent + "  ")
+			e.write(bw, prefixes)
+			bw.WriteByte('\n')
+		}
+	}
+	bw.WriteString(own)
+	bw.WriteString(indent + "</extensions>\n")
+}
+
+// write writes the element with its contents as they were read.
+func (e element) write(bw *bufio.Writer, prefixes map[string]string) {
+	prefixes = namespacePrefixes(prefixes, e.Attrs)
+	var name = qualifiedName(e.XMLName, prefixes)
+	bw.WriteString("<" + name)
+	for _, attr := range e.Attrs {
+		writeAttr(bw, attr, prefixes)
+	}
+	bw.WriteString(">" + e.Inner + "</" + name + ">")
+}
+
+func writeAttr(bw *bufio.Writer, attr xml.Attr, prefixes map[string]string) {
+	fmt.Fprintf(bw, " %s=\"%s\"", qualifiedName(attr.Name, prefixes), escape(attr.Value))
+}
+
+// namespacePrefixes adds the namespace declarations among attrs to prefixes, which maps each
+// namespace to its prefix, empty for the default namespace.
+func namespacePrefixes(prefixes map[string]string, attrs []xml.Attr) map[string]string {
+	var added map[string]string
+	for _, attr := range attrs {
+		var prefix string
+		switch {
+		case attr.Name.Space == "xmlns":
+			prefix = attr.Name.Local
+		case attr.Name == xml.Name{Local: "xmlns"}:
+		default:
+			continue
+		}
+		if added == nil {
+			added = make(map[string]string, len(prefixes)+1)
+			for space, p := range prefixes {
+				added[space] = p
+			}
+		}
+		added[attr.Value] = prefix
+	}
+	if added == nil {
+		return prefixes
+	}
+	return added
+}
+
+// qualifiedName turns a name whose Space is a namespace, as decoded by encoding/xml, back into a
+// prefixed name.
+func qualifiedName(name xml.Name, prefixes map[string]string) string {
+	switch name.Space {
+	case "":
+		return name.Local
+	case "xmlns":
+		return "xmlns:" + name.Local
+	case "http://www.w3.org/XML/1998/namespace":
+		return "xml:" + name.Local
+	}
+	if prefix, ok := prefixes[name.Space]; ok {
+		if prefix == "" {
+			return name.Local
+		}
+		return prefix + ":" + name.Local
+	}
+	// An undeclared prefix is left in Space by encoding/xml.
+	return name.Space + ":" + name.Local
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
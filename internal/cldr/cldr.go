@@ -0,0 +1,53 @@
+// Package cldr reads the parts of the Unicode CLDR data that the generator commands share.
+package cldr
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type bcp47XML struct {
+	Keys []struct {
+		Name  string `xml:"name,attr"`
+		Types []struct {
+			Alias string `xml:"alias,attr"`
+			IANA  string `xml:"iana,attr"`
+		} `xml:"type"`
+	} `xml:"keyword>key"`
+}
+
+// ReadXML decodes the XML file at path into v.
+func ReadXML(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := xml.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s, %w", path, err)
+	}
+	return nil
+}
+
+// IANANames reads bcp47/timezone.xml under the CLDR common directory and maps the CLDR names of zones
+// that IANA has renamed, such as Asia/Calcutta, to their current IANA names. The map is empty when the
+// file does not exist.
+func IANANames(common string) (map[string]string, error) {
+	var iana = make(map[string]string)
+	var keys bcp47XML
+	if err := ReadXML(filepath.Join(common, "bcp47", "timezone.xml"), &keys); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, key := range keys.Keys {
+		for _, t := range key.Types {
+			// The first alias is the name CLDR uses, iana is set when the IANA name differs.
+			if aliases := strings.Fields(t.Alias); key.Name == "tz" && len(aliases) > 0 && t.IANA != "" {
+				iana[aliases[0]] = t.IANA
+			}
+		}
+	}
+	return iana, nil
+}
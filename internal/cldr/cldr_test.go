@@ -0,0 +1,50 @@
+package cldr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testTimezoneKeys = `<?xml version="1.0" encoding="UTF-8" ?>
+<ldmlBCP47>
+	<keyword>
+		<key name="tz" description="Time zone key">
+			<type name="inccu" description="Kolkata, India" alias="Asia/Calcutta Asia/Kolkata" iana="Asia/Kolkata"/>
+			<type name="uschi" description="Chicago, United States" alias="America/Chicago US/Central"/>
+		</key>
+		<key name="ca" description="Calendar key">
+			<type name="gregory" alias="gregorian" iana="not-a-zone"/>
+		</key>
+	</keyword>
+</ldmlBCP47>
+`
+
+func TestIANANames(t *testing.T) {
+	var common = filepath.Join(t.TempDir(), "common")
+	if iana, err := IANANames(common); err != nil || len(iana) != 0 {
+		t.Errorf("missing file: got %v, %v, expected an empty map", iana, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(common, "bcp47"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	var path = filepath.Join(common, "bcp47", "timezone.xml")
+	if err := os.WriteFile(path, []byte(testTimezoneKeys), 0644); err != nil {
+		t.Fatal(err)
+	}
+	iana, err := IANANames(common)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(iana) != 1 || iana["Asia/Calcutta"] != "Asia/Kolkata" {
+		t.Errorf("got %v, expected only Asia/Calcutta renamed to Asia/Kolkata", iana)
+	}
+
+	if err := os.WriteFile(path, []byte("<ldmlBCP47>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := IANANames(common); err == nil {
+		t.Error("expected error for malformed XML")
+	}
+}
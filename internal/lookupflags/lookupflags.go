@@ -0,0 +1,43 @@
+// Package lookupflags defines the command line flags the commands share for configuring time zone lookups.
+package lookupflags
+
+import (
+	"flag"
+	"fmt"
+	"github.com/catmullet/tz"
+)
+
+// Flags holds the lookup flags registered on a flag set.
+type Flags struct {
+	data     *string
+	accuracy *string
+	nautical *bool
+}
+
+// Register adds the -data, -accuracy and -nautical flags to fs.
+func Register(fs *flag.FlagSet) *Flags {
+	return &Flags{
+		data:     fs.String("data", "", "GeoJSON time zone file to load instead of the embedded data"),
+		accuracy: fs.String("accuracy", "fast", "polygon test, fast or exact"),
+		nautical: fs.Bool("nautical", false, "fall back to nautical Etc/GMT zones outside all polygons"),
+	}
+}
+
+// Options returns the options selected by the parsed flags.
+func (f *Flags) Options() ([]tz.Option, error) {
+	var opts []tz.Option
+	switch *f.accuracy {
+	case "fast":
+	case "exact":
+		opts = append(opts, tz.WithAccuracy(tz.AccuracyExact))
+	default:
+		return nil, fmt.Errorf("unknown accuracy %q", *f.accuracy)
+	}
+	if *f.data != "" {
+		opts = append(opts, tz.WithDataFile(*f.data))
+	}
+	if *f.nautical {
+		opts = append(opts, tz.WithNauticalFallback())
+	}
+	return opts, nil
+}
@@ -0,0 +1,33 @@
+package lookupflags
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	var tests = []struct {
+		Args    []string
+		Options int
+		Error   bool
+	}{
+		{Args: nil, Options: 0},
+		{Args: []string{"-accuracy", "exact"}, Options: 1},
+		{Args: []string{"-data", "zones.json", "-nautical", "-accuracy", "fast"}, Options: 2},
+		{Args: []string{"-data", "zones.json", "-nautical", "-accuracy", "exact"}, Options: 3},
+		{Args: []string{"-accuracy", "rough"}, Error: true},
+	}
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		f := Register(fs)
+		if err := fs.Parse(test.Args); err != nil {
+			t.Fatalf("%v: %v", test.Args, err)
+		}
+		opts, err := f.Options()
+		if (err != nil) != test.Error || len(opts) != test.Options {
+			t.Errorf("%v: got %d options, error %v, expected %d options, error %v", test.Args, len(opts), err, test.Options, test.Error)
+		}
+	}
+}
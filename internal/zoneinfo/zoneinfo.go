@@ -0,0 +1,117 @@
+// Package zoneinfo holds the tzdb and CLDR tables embedded in the module, kept apart from the time zone
+// polygons so that packages such as tznames can use them without linking the polygons, and the helpers
+// built on them that package tz exports.
+package zoneinfo
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+)
+
+const backwardFilename = "backward"
+
+// FS holds the tzdb backward links, zone.tab, zone1970.tab and iso3166.tab, and the CLDR Windows
+// mapping written by cmd/windowszones.
+//
+//go:embed backward iso3166.tab windowsZones.txt zone.tab zone1970.tab
+var FS embed.FS
+
+var (
+	aliasesOnce sync.Once
+	aliases     map[string]string
+	aliasesErr  error
+
+	// locations caches loaded time zones by tzid.
+	locations sync.Map
+)
+
+// Aliases returns the tzdb backward links, mapping each alias to its canonical name.
+func Aliases() (map[string]string, error) {
+	aliasesOnce.Do(func() {
+		var b []byte
+		if b, aliasesErr = fs.ReadFile(FS, backwardFilename); aliasesErr == nil {
+			aliases, aliasesErr = parseBackward(b)
+		}
+	})
+	return aliases, aliasesErr
+}
+
+// parseBackward parses Link lines in the format of the tzdb backward file, resolving chains of links.
+func parseBackward(b []byte) (map[string]string, error) {
+	var (
+		links   = make(map[string]string)
+		scanner = bufio.NewScanner(bytes.NewReader(b))
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 || fields[0] != "Link" {
+			return nil, fmt.Errorf("failed to parse %s line %d", backwardFilename, line)
+		}
+		links[fields[2]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for alias, target := range links {
+		for seen := 0; ; seen++ {
+			next, ok := links[target]
+			if !ok {
+				break
+			}
+			if seen == len(links) {
+				return nil, fmt.Errorf("link loop at %s in %s", alias, backwardFilename)
+			}
+			target = next
+		}
+		links[alias] = target
+	}
+	return links, nil
+}
+
+// Canonical returns the canonical IANA name of a time zone, and tzid itself when it is not a known alias.
+func Canonical(tzid string) string {
+	links, _ := Aliases()
+	if target, ok := links[tzid]; ok {
+		return target
+	}
+	return tzid
+}
+
+// LoadLocation is time.LoadLocation with each time zone loaded once and kept for later calls.
+// It is safe for concurrent use.
+func LoadLocation(tzid string) (*time.Location, error) {
+	if loc, ok := locations.Load(tzid); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(tzid, loc)
+	return loc, nil
+}
+
+// StandardOffset estimates the standard offset of loc in a year, in seconds east of UTC, from its offsets
+// in January and July, whichever is smaller. observesDST reports whether the two differ.
+func StandardOffset(loc *time.Location, year int) (offset int, observesDST bool) {
+	_, jan := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).In(loc).Zone()
+	_, jul := time.Date(year, time.July, 1, 0, 0, 0, 0, time.UTC).In(loc).Zone()
+	if jan < jul {
+		return jan, true
+	}
+	return jul, jan != jul
+}
@@ -0,0 +1,29 @@
+package zoneinfo
+
+import "testing"
+
+func TestParseBackward(t *testing.T) {
+	links, err := parseBackward([]byte("# comment\nLink\tB\tA\nLink C B # trailing\n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if links["A"] != "C" || links["B"] != "C" || len(links) != 2 {
+		t.Errorf("got %v", links)
+	}
+	for _, data := range []string{"Zone A B\n", "Link A\n", "Link A B\nLink B A\n"} {
+		if _, err := parseBackward([]byte(data)); err == nil {
+			t.Errorf("%q: expected error", data)
+		}
+	}
+}
+
+func TestAliases(t *testing.T) {
+	if _, err := Aliases(); err != nil {
+		t.Fatal(err)
+	}
+	for alias, expected := range map[string]string{"Asia/Calcutta": "Asia/Kolkata", "Europe/Berlin": "Europe/Berlin"} {
+		if got := Canonical(alias); got != expected {
+			t.Errorf("%s: got %v, expected %v", alias, got, expected)
+		}
+	}
+}
@@ -1,20 +1,20 @@
 package tz
 
 import (
-	"embed"
 	"encoding/json"
 	"github.com/golang/snappy"
+	"io/fs"
 	"log"
 	"strings"
 )
 
 type LocalGeoStorage struct {
-	efs embed.FS
+	efs fs.FS
 }
 
-func newLocalGeoStorage(fs embed.FS) geoStorage {
+func newLocalGeoStorage(fsys fs.FS) geoStorage {
 	return &LocalGeoStorage{
-		efs: fs,
+		efs: fsys,
 	}
 }
 
@@ -24,15 +24,15 @@ func (lgs LocalGeoStorage) StoreFile(_ string, _ interface{}) error {
 }
 
 func (lgs LocalGeoStorage) LoadFile(filename string, obj interface{}) ([]byte, error) {
-	var decodedJson, err = lgs.efs.ReadFile(filename)
+	var decodedJson, err = fs.ReadFile(lgs.efs, filename)
+	if err != nil {
+		return decodedJson, err
+	}
 	if strings.Contains(filename, "snappy") {
 		decodedJson, err = snappy.Decode(nil, decodedJson)
 		if err != nil {
 			return decodedJson, err
 		}
 	}
-	if err != nil {
-		return decodedJson, err
-	}
 	return decodedJson, json.Unmarshal(decodedJson, &obj)
 }
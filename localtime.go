@@ -0,0 +1,55 @@
+package tz
+
+import (
+	"github.com/catmullet/tz/internal/zoneinfo"
+	"time"
+)
+
+// LoadLocation is time.LoadLocation with each time zone loaded once and kept for later calls.
+// It is safe for concurrent use.
+func LoadLocation(tzid string) (*time.Location, error) {
+	return zoneinfo.LoadLocation(tzid)
+}
+
+// LocalTime is the wall clock at a coordinate for an instant.
+type LocalTime struct {
+	TZID string
+	// Time is the instant in the time zone at the coordinate.
+	Time time.Time
+	// Offset is the UTC offset in seconds east of UTC.
+	Offset       int
+	Abbreviation string
+	// DST reports whether Offset is ahead of the zone's standard offset for the year,
+	// taken as the smaller of its offsets on the 1st of January and the 1st of July.
+	DST bool
+	// Previous and Next are the transitions around the instant within ten years, nil if there is none.
+	Previous *Transition
+	Next     *Transition
+}
+
+// LocalTime returns the local time, offset and surrounding transitions at a coordinate for the instant t.
+func (fc Collection) LocalTime(lat, lon float64, t time.Time) (LocalTime, error) {
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return LocalTime{}, err
+	}
+	loc, err := LoadLocation(tzid)
+	if err != nil {
+		return LocalTime{}, err
+	}
+
+	var lt = LocalTime{TZID: tzid, Time: t.In(loc)}
+	lt.Abbreviation, lt.Offset = lt.Time.Zone()
+	std, _ := StandardOffset(loc, lt.Time.Year())
+	lt.DST = lt.Offset > std
+
+	lt.Previous, lt.Next = surroundingTransitions(loc, t)
+	return lt, nil
+}
+
+// StandardOffset estimates the standard offset of loc in a year, in seconds east of UTC, from its offsets
+// in January and July, whichever is smaller, which works for both hemispheres. observesDST reports
+// whether the two differ.
+func StandardOffset(loc *time.Location, year int) (offset int, observesDST bool) {
+	return zoneinfo.StandardOffset(loc, year)
+}
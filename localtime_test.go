@@ -0,0 +1,97 @@
+package tz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocalTime(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var tests = []struct {
+		Name         string
+		Lat, Lon     float64
+		At           time.Time
+		TZID         string
+		Local        string
+		Abbreviation string
+		Offset       int
+		DST          bool
+		Previous     time.Time
+		Next         time.Time
+	}{
+		{Name: "denver summer", Lat: 40.5, Lon: -109.5, At: time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC),
+			TZID: "America/Denver", Local: "2026-07-01T06:00:00-06:00", Abbreviation: "MDT", Offset: -6 * 3600, DST: true,
+			Previous: time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC), Next: time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC)},
+		{Name: "denver winter", Lat: 40.5, Lon: -109.5, At: time.Date(2026, 12, 24, 18, 0, 0, 0, time.UTC),
+			TZID: "America/Denver", Local: "2026-12-24T11:00:00-07:00", Abbreviation: "MST", Offset: -7 * 3600,
+			Previous: time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC), Next: time.Date(2027, 3, 14, 9, 0, 0, 0, time.UTC)},
+		{Name: "at a transition", Lat: 40.5, Lon: -109.5, At: time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC),
+			TZID: "America/Denver", Local: "2026-03-08T03:00:00-06:00", Abbreviation: "MDT", Offset: -6 * 3600, DST: true,
+			Previous: time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC), Next: time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC)},
+		{Name: "after new year", Lat: 40.5, Lon: -109.5, At: time.Date(2027, 1, 1, 2, 0, 0, 0, time.UTC),
+			TZID: "America/Denver", Local: "2026-12-31T19:00:00-07:00", Abbreviation: "MST", Offset: -7 * 3600,
+			Previous: time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC), Next: time.Date(2027, 3, 14, 9, 0, 0, 0, time.UTC)},
+		{Name: "fiji without dst", Lat: -17, Lon: 178, At: time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC),
+			TZID: "Pacific/Fiji", Local: "2026-07-02T00:00:00+12:00", Abbreviation: "+12", Offset: 12 * 3600,
+			Previous: time.Date(2021, 1, 16, 14, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		lt, err := fc.LocalTime(test.Lat, test.Lon, test.At)
+		if err != nil {
+			t.Errorf("%s: %v", test.Name, err)
+			continue
+		}
+		if lt.TZID != test.TZID || lt.Time.Format(time.RFC3339) != test.Local || lt.Abbreviation != test.Abbreviation ||
+			lt.Offset != test.Offset || lt.DST != test.DST {
+			t.Errorf("%s: got %s %s %s %d %v, expected %s %s %s %d %v", test.Name, lt.TZID, lt.Time.Format(time.RFC3339),
+				lt.Abbreviation, lt.Offset, lt.DST, test.TZID, test.Local, test.Abbreviation, test.Offset, test.DST)
+		}
+		if !transitionAt(lt.Previous, test.Previous) || !transitionAt(lt.Next, test.Next) {
+			t.Errorf("%s: got transitions %+v %+v, expected %v %v", test.Name, lt.Previous, lt.Next, test.Previous, test.Next)
+		}
+	}
+
+	if _, err := fc.LocalTime(40.5, -107, time.Now()); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
+
+func TestTransitionOffsets(t *testing.T) {
+	loc, err := LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := nextTransition(loc, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	var want = Transition{At: time.Date(2026, 4, 4, 16, 0, 0, 0, time.UTC), OffsetBefore: 11 * 3600, OffsetAfter: 10 * 3600,
+		AbbreviationBefore: "AEDT", AbbreviationAfter: "AEST"}
+	if !ok || tr != want {
+		t.Errorf("got %+v, expected %+v", tr, want)
+	}
+	if offset, dst := StandardOffset(loc, 2026); offset != 10*3600 || !dst {
+		t.Errorf("got standard offset %d %v, expected %d true", offset, dst, 10*3600)
+	}
+	if offset, dst := StandardOffset(time.UTC, 2026); offset != 0 || dst {
+		t.Errorf("got standard offset %d %v for UTC, expected 0 false", offset, dst)
+	}
+	if again, _ := LoadLocation("Australia/Sydney"); again != loc {
+		t.Error("expected cached location")
+	}
+}
+
+func BenchmarkLocalTime(b *testing.B) {
+	var fc = newTestCollection(b, featureGeoJSON)
+	var at = time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fc.LocalTime(-17, 178, at); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func transitionAt(tr *Transition, at time.Time) bool {
+	if tr == nil {
+		return at.IsZero()
+	}
+	return tr.At.Equal(at)
+}
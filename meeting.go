@@ -0,0 +1,178 @@
+package tz
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// meetingStep spaces the candidate start times of PlanMeeting, counted from midnight of the meeting date.
+	meetingStep = 15 * time.Minute
+	// defaultWorkStart and defaultWorkEnd are the working hours of participants that leave them unset.
+	defaultWorkStart = 9 * time.Hour
+	defaultWorkEnd   = 17 * time.Hour
+)
+
+// Participant is a person to meet, at a coordinate and available during working hours.
+type Participant struct {
+	Lat, Lon float64
+	// Start and End bound the working hours as local durations since midnight, 9h to 17h when both are
+	// zero. A Start after End wraps past midnight.
+	Start, End time.Duration
+}
+
+// MeetingSlot is a time when every participant of a meeting is within working hours.
+type MeetingSlot struct {
+	Start, End time.Time
+	// Local holds the start of the slot in the time zone of each participant, in the order given.
+	Local []time.Time
+	// Score is 1 for a slot in the middle of every participant's working hours and falls to 0 as the slot
+	// reaches the start or end of someone's working hours.
+	Score float64
+}
+
+// interval is the span of time [start, end).
+type interval struct {
+	start, end time.Time
+}
+
+// PlanMeeting returns the slots of length d on a date when every participant is within working hours,
+// best first. The date is the calendar day of date in the time zone of the first participant, and slots
+// start every 15 minutes from its midnight. Working hours follow each participant's wall clock, so a
+// daylight saving change shifts them in UTC. A slot ranks by its worst placed participant, ties go to the
+// earlier slot.
+func (fc Collection) PlanMeeting(participants []Participant, date time.Time, d time.Duration) ([]MeetingSlot, error) {
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("no participants")
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid meeting length %v", d)
+	}
+
+	participants = append([]Participant(nil), participants...)
+	var locs = make([]*time.Location, len(participants))
+	for i, p := range participants {
+		if p.Start == 0 && p.End == 0 {
+			p.Start, p.End = defaultWorkStart, defaultWorkEnd
+			participants[i] = p
+		}
+		if p.Start < 0 || p.Start > 24*time.Hour || p.End < 0 || p.End > 24*time.Hour || p.Start == p.End {
+			return nil, fmt.Errorf("invalid working hours %v to %v", p.Start, p.End)
+		}
+		tzid, err := fc.timeZone(p.Lat, p.Lon)
+		if err != nil {
+			return nil, err
+		}
+		if locs[i], err = LoadLocation(tzid); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		y, m, day = date.In(locs[0]).Date()
+		window    = interval{time.Date(y, m, day, 0, 0, 0, 0, locs[0]), time.Date(y, m, day+1, 0, 0, 0, 0, locs[0])}
+		common    = []interval{window}
+	)
+	for i, p := range participants {
+		common = intersectIntervals(common, workingIntervals(p, locs[i], window))
+	}
+
+	var slots []MeetingSlot
+	for _, free := range common {
+		// Starts are aligned to the step from the start of the date.
+		start := window.start.Add((free.start.Sub(window.start) + meetingStep - 1) / meetingStep * meetingStep)
+		for ; !start.Add(d).After(free.end); start = start.Add(meetingStep) {
+			slots = append(slots, meetingSlot(participants, locs, start.UTC(), d))
+		}
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].Score > slots[j].Score
+	})
+	return slots, nil
+}
+
+// workingIntervals returns the working hours of a participant that overlap the window, sorted.
+func workingIntervals(p Participant, loc *time.Location, window interval) []interval {
+	var (
+		intervals []interval
+		y, m, d   = window.start.In(loc).Date()
+	)
+	// Working hours that wrap past midnight begin the day before.
+	for day := d - 1; ; day++ {
+		iv := workingDay(p, loc, y, m, day)
+		if !iv.start.Before(window.end) {
+			return intervals
+		}
+		if iv.end.After(window.start) {
+			intervals = append(intervals, iv)
+		}
+	}
+}
+
+// workingDay returns the working hours of a participant that start on a local date.
+func workingDay(p Participant, loc *time.Location, y int, m time.Month, d int) interval {
+	var end = d
+	if p.End <= p.Start {
+		end++
+	}
+	return interval{wallClock(loc, y, m, d, p.Start), wallClock(loc, y, m, end, p.End)}
+}
+
+// wallClock returns the instant a local clock in loc reads offset past midnight of a date.
+func wallClock(loc *time.Location, y int, m time.Month, d int, offset time.Duration) time.Time {
+	return time.Date(y, m, d, int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second), 0, loc)
+}
+
+// intersectIntervals returns the spans covered by both sorted lists of intervals.
+func intersectIntervals(a, b []interval) []interval {
+	var result []interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].start, a[i].end
+		if b[j].start.After(start) {
+			start = b[j].start
+		}
+		if b[j].end.Before(end) {
+			end = b[j].end
+		}
+		if start.Before(end) {
+			result = append(result, interval{start, end})
+		}
+		if a[i].end.Before(b[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// meetingSlot builds the slot of length d at start and scores it.
+func meetingSlot(participants []Participant, locs []*time.Location, start time.Time, d time.Duration) MeetingSlot {
+	var slot = MeetingSlot{Start: start, End: start.Add(d), Local: make([]time.Time, len(participants)), Score: 1}
+	var mid = start.Add(d / 2)
+	for i, p := range participants {
+		slot.Local[i] = start.In(locs[i])
+		y, m, day := slot.Local[i].Date()
+		iv := workingDay(p, locs[i], y, m, day)
+		if iv.start.After(start) {
+			iv = workingDay(p, locs[i], y, m, day-1)
+		}
+		var (
+			center = iv.start.Add(iv.end.Sub(iv.start) / 2)
+			slack  = (iv.end.Sub(iv.start) - d) / 2
+			score  = 1.0
+		)
+		if slack > 0 {
+			off := mid.Sub(center)
+			if off < 0 {
+				off = -off
+			}
+			score = 1 - float64(off)/float64(slack)
+		}
+		if score < slot.Score {
+			slot.Score = score
+		}
+	}
+	return slot
+}
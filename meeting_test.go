@@ -0,0 +1,107 @@
+package tz
+
+import (
+	"testing"
+	"time"
+)
+
+// meetingGeoJSON has rectangles around New York, Berlin and Tokyo.
+const meetingGeoJSON = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "America/New_York"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-75, 40], [-73, 40], [-73, 42], [-75, 42], [-75, 40]]]}},
+	{"type": "Feature", "properties": {"tzid": "Europe/Berlin"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[13, 52], [14, 52], [14, 53], [13, 53], [13, 52]]]}},
+	{"type": "Feature", "properties": {"tzid": "Asia/Tokyo"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[139, 35], [140, 35], [140, 36], [139, 36], [139, 35]]]}}
+]}`
+
+func TestPlanMeeting(t *testing.T) {
+	var (
+		fc      = newTestCollection(t, meetingGeoJSON)
+		newYork = Participant{Lat: 40.7, Lon: -74}
+		berlin  = Participant{Lat: 52.5, Lon: 13.4}
+		tokyo   = Participant{Lat: 35.7, Lon: 139.7}
+		night   = Participant{Lat: 40.7, Lon: -74, Start: 22 * time.Hour, End: 6 * time.Hour}
+		utc     = func(month time.Month, day, hour, min int) time.Time {
+			return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+		}
+	)
+	var tests = []struct {
+		Name         string
+		Participants []Participant
+		Date         time.Time
+		First, Last  time.Time
+		Slots        int
+		Best         time.Time
+	}{
+		// 9 to 17 is 13:00 to 21:00 UTC in New York and 7:00 to 15:00 UTC in Berlin.
+		{Name: "summer", Participants: []Participant{newYork, berlin}, Date: utc(time.July, 10, 12, 0),
+			First: utc(time.July, 10, 13, 0), Last: utc(time.July, 10, 14, 0), Slots: 5, Best: utc(time.July, 10, 13, 30)},
+		// New York has moved to daylight saving time, Berlin has not, widening the overlap to 13:00 to 16:00.
+		{Name: "between the changes", Participants: []Participant{newYork, berlin}, Date: utc(time.March, 20, 12, 0),
+			First: utc(time.March, 20, 13, 0), Last: utc(time.March, 20, 15, 0), Slots: 9, Best: utc(time.March, 20, 14, 0)},
+		{Name: "winter", Participants: []Participant{newYork, berlin}, Date: utc(time.March, 5, 12, 0),
+			First: utc(time.March, 5, 14, 0), Last: utc(time.March, 5, 15, 0), Slots: 5, Best: utc(time.March, 5, 14, 30)},
+		// The night shift from 22:00 EST to 6:00 EDT ends at 10:00 UTC, an hour earlier than without the change.
+		{Name: "night shift across the change", Participants: []Participant{night, berlin}, Date: utc(time.March, 10, 12, 0),
+			First: utc(time.March, 10, 8, 0), Last: utc(time.March, 10, 9, 0), Slots: 5},
+		{Name: "no overlap", Participants: []Participant{newYork, tokyo}, Date: utc(time.July, 10, 12, 0)},
+		{Name: "alone", Participants: []Participant{tokyo}, Date: utc(time.July, 10, 12, 0),
+			First: utc(time.July, 10, 0, 0), Last: utc(time.July, 10, 7, 0), Slots: 29, Best: utc(time.July, 10, 3, 30)},
+	}
+	for _, test := range tests {
+		slots, err := fc.PlanMeeting(test.Participants, test.Date, time.Hour)
+		if err != nil {
+			t.Errorf("%s: %v", test.Name, err)
+			continue
+		}
+		if len(slots) != test.Slots {
+			t.Errorf("%s: got %d slots, expected %d", test.Name, len(slots), test.Slots)
+			continue
+		}
+		if len(slots) == 0 {
+			continue
+		}
+		var first, last = slots[0].Start, slots[0].Start
+		for i, slot := range slots {
+			if slot.Start.Before(first) {
+				first = slot.Start
+			}
+			if slot.Start.After(last) {
+				last = slot.Start
+			}
+			if slot.End.Sub(slot.Start) != time.Hour || len(slot.Local) != len(test.Participants) {
+				t.Errorf("%s: got slot %+v", test.Name, slot)
+			}
+			if i > 0 && slot.Score > slots[i-1].Score {
+				t.Errorf("%s: slot %d scores %v after %v", test.Name, i, slot.Score, slots[i-1].Score)
+			}
+		}
+		if !first.Equal(test.First) || !last.Equal(test.Last) {
+			t.Errorf("%s: got slots from %v to %v, expected %v to %v", test.Name, first, last, test.First, test.Last)
+		}
+		if !test.Best.IsZero() && !slots[0].Start.Equal(test.Best) {
+			t.Errorf("%s: got best slot %v, expected %v", test.Name, slots[0].Start, test.Best)
+		}
+	}
+
+	slots, _ := fc.PlanMeeting([]Participant{newYork, berlin}, utc(time.July, 10, 12, 0), time.Hour)
+	if local := slots[0].Local; local[0].Hour() != 9 || local[0].Minute() != 30 || local[1].Hour() != 15 {
+		t.Errorf("got local starts %v, expected 9:30 in New York and 15:30 in Berlin", local)
+	}
+
+	var errorTests = map[string][]Participant{
+		"no participants":  nil,
+		"outside zones":    {{Lat: 0, Lon: 0}},
+		"empty hours":      {{Lat: 40.7, Lon: -74, Start: 9 * time.Hour, End: 9 * time.Hour}},
+		"hours past a day": {{Lat: 40.7, Lon: -74, Start: 9 * time.Hour, End: 25 * time.Hour}},
+	}
+	for name, participants := range errorTests {
+		if _, err := fc.PlanMeeting(participants, time.Now(), time.Hour); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := fc.PlanMeeting([]Participant{newYork}, time.Now(), 0); err == nil {
+		t.Error("zero length: expected error")
+	}
+}
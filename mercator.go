@@ -0,0 +1,29 @@
+package tz
+
+import (
+	"fmt"
+	"math"
+)
+
+// webMercatorMax is the largest easting and northing of EPSG:3857 Web Mercator, reached at longitude 180
+// and latitude 85.05112878.
+const webMercatorMax = math.Pi * wgs84A
+
+// WebMercatorToLatLon converts EPSG:3857 Web Mercator meters to WGS84 latitude and longitude.
+func WebMercatorToLatLon(x, y float64) (lat, lon float64, err error) {
+	if math.IsNaN(x) || math.IsNaN(y) || math.Abs(x) > webMercatorMax+1e-6 || math.Abs(y) > webMercatorMax+1e-6 {
+		return 0, 0, fmt.Errorf("web mercator coordinate %v %v out of range", x, y)
+	}
+	lon = x / wgs84A * 180 / math.Pi
+	lat = (2*math.Atan(math.Exp(y/wgs84A)) - math.Pi/2) * 180 / math.Pi
+	return lat, lon, nil
+}
+
+// TimeZoneWebMercator returns the time zone at an EPSG:3857 Web Mercator coordinate.
+func (fc Collection) TimeZoneWebMercator(x, y float64) (string, error) {
+	lat, lon, err := WebMercatorToLatLon(x, y)
+	if err != nil {
+		return "", err
+	}
+	return fc.timeZone(lat, lon)
+}
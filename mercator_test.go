@@ -0,0 +1,35 @@
+package tz
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWebMercatorToLatLon(t *testing.T) {
+	var tests = []struct {
+		X, Y     float64
+		Lat, Lon float64
+	}{
+		{X: 0, Y: 0, Lat: 0, Lon: 0},
+		{X: 20037508.342789244, Y: 20037508.342789244, Lat: 85.0511287798066, Lon: 180},
+		{X: -20037508.342789244, Y: -20037508.342789244, Lat: -85.0511287798066, Lon: -180},
+		{X: -13627361.0, Y: 4544761.0, Lat: 37.754204, Lon: -122.416667},
+		{X: 261845.7, Y: 6250564.35, Lat: 48.8566, Lon: 2.3522},
+	}
+	for _, test := range tests {
+		lat, lon, err := WebMercatorToLatLon(test.X, test.Y)
+		if err != nil {
+			t.Errorf("%v %v: %v", test.X, test.Y, err)
+			continue
+		}
+		if math.Abs(lat-test.Lat) > 1e-6 || math.Abs(lon-test.Lon) > 1e-6 {
+			t.Errorf("%v %v: got %v %v, expected %v %v", test.X, test.Y, lat, lon, test.Lat, test.Lon)
+		}
+	}
+
+	for _, p := range [][2]float64{{20037509, 0}, {0, -20037509}, {math.NaN(), 0}} {
+		if _, _, err := WebMercatorToLatLon(p[0], p[1]); err == nil {
+			t.Errorf("%v: expected error", p)
+		}
+	}
+}
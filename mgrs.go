@@ -0,0 +1,90 @@
+package tz
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	mgrsBands    = "CDEFGHJKLMNPQRSTUVWX"
+	mgrsRows     = "ABCDEFGHJKLMNPQRSTUV"
+	mgrsSquare   = 100000.0
+	mgrsRowCycle = 2000000.0
+)
+
+// mgrsColumns holds the 100km column letters of zones 3n, 3n+1 and 3n+2.
+var mgrsColumns = [3]string{"STUVWXYZ", "ABCDEFGH", "JKLMNPQR"}
+
+// ParseMGRS parses a Military Grid Reference System reference such as 18SUJ2337106519 or 18S UJ 23371 06519.
+// It returns the UTM coordinate of the south west corner of the referenced square and the square's size in meters.
+// The polar UPS areas, bands A, B, Y and Z, are not supported.
+func ParseMGRS(ref string) (u UTM, precision float64, err error) {
+	var invalid = fmt.Errorf("invalid MGRS reference %q", ref)
+	var s = strings.ToUpper(strings.Join(strings.Fields(ref), ""))
+
+	var i = 0
+	for i < len(s) && i < 2 && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || len(s) < i+3 {
+		return UTM{}, 0, invalid
+	}
+	if u.Zone, err = strconv.Atoi(s[:i]); err != nil || u.Zone < 1 || u.Zone > 60 {
+		return UTM{}, 0, invalid
+	}
+
+	band := strings.IndexByte(mgrsBands, s[i])
+	column := strings.IndexByte(mgrsColumns[u.Zone%3], s[i+1])
+	row := strings.IndexByte(mgrsRows, s[i+2])
+	if band < 0 || column < 0 || row < 0 {
+		return UTM{}, 0, invalid
+	}
+
+	digits := s[i+3:]
+	if len(digits)%2 == 1 || len(digits) > 10 {
+		return UTM{}, 0, invalid
+	}
+	precision = mgrsSquare
+	var easting, northing float64
+	if half := len(digits) / 2; half > 0 {
+		precision = math.Pow(10, float64(5-half))
+		e, errE := strconv.ParseUint(digits[:half], 10, 32)
+		n, errN := strconv.ParseUint(digits[half:], 10, 32)
+		if errE != nil || errN != nil {
+			return UTM{}, 0, invalid
+		}
+		easting, northing = float64(e)*precision, float64(n)*precision
+	}
+
+	// Row letters repeat every 2000km and start 5 letters later in even zones.
+	if u.Zone%2 == 0 {
+		row = (row + len(mgrsRows) - 5) % len(mgrsRows)
+	}
+	u.Easting = float64(column+1)*mgrsSquare + easting
+	u.Northing = float64(row)*mgrsSquare + northing
+
+	// Lift the northing into the latitude band, whose lowest northing is on the central meridian.
+	bandLat := -80 + 8*float64(band)
+	u.North = bandLat >= 0
+	minNorthing := toUTM(bandLat, utmCentralMeridian(u.Zone), u.Zone).Northing
+	for u.Northing < minNorthing-mgrsSquare {
+		u.Northing += mgrsRowCycle
+	}
+	if u.Northing > utmFalseNorthing {
+		return UTM{}, 0, invalid
+	}
+	return u, precision, nil
+}
+
+// TimeZoneMGRS returns the time zone at the center of the square referenced by an MGRS reference, see ParseMGRS.
+func (fc Collection) TimeZoneMGRS(ref string) (string, error) {
+	u, precision, err := ParseMGRS(ref)
+	if err != nil {
+		return "", err
+	}
+	u.Easting += precision / 2
+	u.Northing += precision / 2
+	return fc.TimeZoneUTM(u)
+}
@@ -0,0 +1,43 @@
+package tz
+
+import (
+	"fmt"
+	"math"
+)
+
+// nauticalZoneWidth is the width in degrees of longitude of a nautical time zone.
+const nauticalZoneWidth = 15.0
+
+// NauticalTimeZone returns the nautical Etc/GMT zone for a longitude without any polygon tests.
+// Zones are 15° bands centered on multiples of 15°, so Etc/GMT covers -7.5° to 7.5°. The band
+// straddling the antimeridian is split by the date line: +180 resolves to Etc/GMT-12 and -180 to
+// Etc/GMT+12. Longitudes outside [-180, 180] are wrapped, and NaN yields an empty string.
+//
+// Note the POSIX sign convention of the Etc zones: Etc/GMT+5 is five hours behind UTC.
+func NauticalTimeZone(lon float64) string {
+	if math.IsNaN(lon) || math.IsInf(lon, 0) {
+		return ""
+	}
+	var zone = nauticalZone(normalizeLon(lon))
+	if zone == 0 {
+		return "Etc/GMT"
+	}
+	return fmt.Sprintf("Etc/GMT%+d", -zone)
+}
+
+// nauticalZone returns the hour offset east of UTC for a longitude within [-180, 180].
+func nauticalZone(lon float64) int {
+	return int(math.Floor((lon + nauticalZoneWidth/2) / nauticalZoneWidth))
+}
+
+// normalizeLon wraps a longitude into [-180, 180], leaving values already in range untouched.
+func normalizeLon(lon float64) float64 {
+	if lon >= -180 && lon <= 180 {
+		return lon
+	}
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
@@ -0,0 +1,60 @@
+package tz
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNauticalTimeZone(t *testing.T) {
+	var tests = []struct {
+		Lon  float64
+		TZID string
+	}{
+		{Lon: 0, TZID: "Etc/GMT"},
+		{Lon: 7.4999, TZID: "Etc/GMT"},
+		{Lon: -7.5, TZID: "Etc/GMT"},
+		{Lon: 7.5, TZID: "Etc/GMT-1"},
+		{Lon: -7.5001, TZID: "Etc/GMT+1"},
+		{Lon: -75.2, TZID: "Etc/GMT+5"},
+		{Lon: 139.7, TZID: "Etc/GMT-9"},
+		{Lon: 172.4999, TZID: "Etc/GMT-11"},
+		{Lon: 172.5, TZID: "Etc/GMT-12"},
+		{Lon: 180, TZID: "Etc/GMT-12"},
+		{Lon: -180, TZID: "Etc/GMT+12"},
+		{Lon: -172.5001, TZID: "Etc/GMT+12"},
+		{Lon: 190, TZID: "Etc/GMT+11"},
+		{Lon: -545, TZID: "Etc/GMT-12"},
+		{Lon: math.NaN(), TZID: ""},
+	}
+	for _, tt := range tests {
+		if tzid := NauticalTimeZone(tt.Lon); tzid != tt.TZID {
+			t.Errorf("lon %v: got %q, expected %q", tt.Lon, tzid, tt.TZID)
+		}
+	}
+}
+
+func TestNauticalTimeZoneLoadsLocation(t *testing.T) {
+	for lon := -180.0; lon <= 180; lon += 7.5 {
+		tzid := NauticalTimeZone(lon)
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, offset := time.Now().In(loc).Zone()
+		if offset != nauticalZone(lon)*3600 {
+			t.Errorf("lon %v: %s has offset %d", lon, tzid, offset)
+		}
+	}
+}
+
+func TestNauticalFallback(t *testing.T) {
+	var fc Collection
+	if tzid := fc.TimeZone(-40, -120); tzid != "" {
+		t.Errorf("got %q without fallback", tzid)
+	}
+	WithNauticalFallback()(&fc)
+	if tzid := fc.TimeZone(-40, -120); tzid != "Etc/GMT+8" {
+		t.Errorf("got %q, expected Etc/GMT+8", tzid)
+	}
+}
@@ -0,0 +1,187 @@
+// Package nmea reads position fixes from NMEA 0183 streams and resolves the time zone of each one.
+package nmea
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fix is a position from an RMC or GGA sentence. Time is in UTC and is zero for a GGA sentence
+// read before any RMC sentence has given the date.
+type Fix struct {
+	Sentence string
+	Lat      float64
+	Lon      float64
+	Time     time.Time
+}
+
+// Reader reads fixes from a stream of NMEA sentences. Sentences of other types, sentences without
+// a valid fix and sentences failing their checksum are skipped.
+type Reader struct {
+	s    *bufio.Scanner
+	date time.Time
+	// last is the time of the last RMC sentence, which dates the GGA sentences after it.
+	last time.Time
+	// Skipped counts the sentences that were skipped because they could not be parsed.
+	Skipped int
+}
+
+// NewReader returns a Reader reading sentences from r, one per line.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{s: bufio.NewScanner(r)}
+}
+
+var (
+	errChecksum = errors.New("checksum mismatch")
+	errNoFix    = errors.New("no fix")
+)
+
+// Next returns the next fix, or io.EOF at the end of the stream.
+func (r *Reader) Next() (Fix, error) {
+	for r.s.Scan() {
+		line := strings.TrimSpace(r.s.Text())
+		if !strings.HasPrefix(line, "$") || len(line) < 7 {
+			continue
+		}
+		fix, ok, err := r.parse(line)
+		if err != nil {
+			if err != errNoFix {
+				r.Skipped++
+			}
+			continue
+		}
+		if ok {
+			return fix, nil
+		}
+	}
+	if err := r.s.Err(); err != nil {
+		return Fix{}, err
+	}
+	return Fix{}, io.EOF
+}
+
+// parse reads one sentence, reporting false for sentence types that carry no fix.
+func (r *Reader) parse(line string) (Fix, bool, error) {
+	body := line[1:]
+	if i := strings.IndexByte(body, '*'); i >= 0 {
+		want, err := strconv.ParseUint(body[i+1:], 16, 8)
+		if err != nil {
+			return Fix{}, false, errChecksum
+		}
+		body = body[:i]
+		var sum byte
+		for j := 0; j < len(body); j++ {
+			sum ^= body[j]
+		}
+		if uint64(sum) != want {
+			return Fix{}, false, errChecksum
+		}
+	}
+
+	fields := strings.Split(body, ",")
+	if len(fields[0]) != 5 {
+		return Fix{}, false, nil
+	}
+	switch sentence := fields[0][2:]; sentence {
+	case "RMC":
+		// $--RMC,hhmmss.ss,A,llll.ll,a,yyyyy.yy,a,x.x,x.x,ddmmyy,...
+		if len(fields) < 10 {
+			return Fix{}, false, fmt.Errorf("short %s sentence", sentence)
+		}
+		if fields[2] != "A" {
+			return Fix{}, false, errNoFix
+		}
+		date, err := time.Parse("020106", fields[9])
+		if err != nil {
+			return Fix{}, false, err
+		}
+		r.date = date
+		fix, ok, err := r.fix(sentence, fields[1], fields[3:7])
+		if err == nil {
+			r.last = fix.Time
+		}
+		return fix, ok, err
+	case "GGA":
+		// $--GGA,hhmmss.ss,llll.ll,a,yyyyy.yy,a,x,...
+		if len(fields) < 7 {
+			return Fix{}, false, fmt.Errorf("short %s sentence", sentence)
+		}
+		if fields[6] == "" || fields[6] == "0" {
+			return Fix{}, false, errNoFix
+		}
+		return r.fix(sentence, fields[1], fields[2:6])
+	default:
+		return Fix{}, false, nil
+	}
+}
+
+func (r *Reader) fix(sentence, clock string, pos []string) (Fix, bool, error) {
+	lat, err := parseCoordinate(pos[0], pos[1], 2, "N", "S")
+	if err != nil {
+		return Fix{}, false, err
+	}
+	lon, err := parseCoordinate(pos[2], pos[3], 3, "E", "W")
+	if err != nil {
+		return Fix{}, false, err
+	}
+	var fix = Fix{Sentence: sentence, Lat: lat, Lon: lon}
+	if !r.date.IsZero() {
+		if fix.Time, err = parseClock(r.date, clock); err != nil {
+			return Fix{}, false, err
+		}
+		// Receivers often send GGA ahead of RMC, so a GGA clock far behind the last RMC crossed midnight.
+		if sentence == "GGA" && fix.Time.Before(r.last.Add(-12*time.Hour)) {
+			fix.Time = fix.Time.AddDate(0, 0, 1)
+		}
+	}
+	return fix, true, nil
+}
+
+// parseCoordinate reads an NMEA angle: degrees in the first width digits followed by decimal minutes.
+func parseCoordinate(value, hemisphere string, width int, positive, negative string) (float64, error) {
+	if len(value) < width+2 {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+	deg, err := strconv.Atoi(value[:width])
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+	minutes, err := strconv.ParseFloat(value[width:], 64)
+	if err != nil || minutes >= 60 {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+	var angle = float64(deg) + minutes/60
+	switch hemisphere {
+	case positive:
+		return angle, nil
+	case negative:
+		return -angle, nil
+	default:
+		return 0, fmt.Errorf("invalid hemisphere %q", hemisphere)
+	}
+}
+
+// parseClock combines a date with an hhmmss.ss time of day.
+func parseClock(date time.Time, clock string) (time.Time, error) {
+	if len(clock) < 6 {
+		return time.Time{}, fmt.Errorf("invalid time %q", clock)
+	}
+	t, err := time.Parse("150405", clock[:6])
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int
+	if len(clock) > 7 && clock[6] == '.' {
+		frac, err := strconv.ParseFloat("0"+clock[6:], 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q", clock)
+		}
+		nsec = int(frac*1e9 + 0.5)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), nsec, time.UTC), nil
+}
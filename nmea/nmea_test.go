@@ -0,0 +1,213 @@
+package nmea
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/catmullet/tz"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sentence adds the leading $ and the checksum to a sentence body.
+func sentence(body string) string {
+	var sum byte
+	for i := 0; i < len(body); i++ {
+		sum ^= body[i]
+	}
+	return fmt.Sprintf("$%s*%02X", body, sum)
+}
+
+func TestReader(t *testing.T) {
+	var stream = strings.Join([]string{
+		sentence("GPGGA,235959.00,4124.8963,N,08151.6838,W,1,05,1.5,280.2,M,-34.0,M,,"),
+		sentence("GPRMC,000001.50,A,4807.038,N,01131.000,E,022.4,084.4,010126,003.1,W"),
+		sentence("GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00"),
+		sentence("GNGGA,000002,3352.8000,S,15112.6000,E,2,08,0.9,10.0,M,20.0,M,,"),
+		sentence("GPRMC,000003,V,4807.038,N,01131.000,E,,,010126,,"),
+		sentence("GPGGA,000004,4807.038,N,01131.000,E,0,00,,,M,,M,,"),
+		"$GPRMC,000005,A,4807.038,N,01131.000,E,022.4,084.4,010126,003.1,W*00",
+		sentence("GPRMC,000006,A,4807.038,X,01131.000,E,022.4,084.4,010126,003.1,W"),
+		"garbage",
+		sentence("GPRMC,000007,A,0000.000,N,00000.000,W,0,0,311299,,"),
+	}, "\r\n")
+
+	var want = []Fix{
+		{Sentence: "GGA", Lat: 41 + 24.8963/60, Lon: -(81 + 51.6838/60)},
+		{Sentence: "RMC", Lat: 48 + 7.038/60, Lon: 11 + 31.0/60, Time: time.Date(2026, 1, 1, 0, 0, 1, 5e8, time.UTC)},
+		{Sentence: "GGA", Lat: -(33 + 52.8/60), Lon: 151 + 12.6/60, Time: time.Date(2026, 1, 1, 0, 0, 2, 0, time.UTC)},
+		{Sentence: "RMC", Lat: 0, Lon: 0, Time: time.Date(1999, 12, 31, 0, 0, 7, 0, time.UTC)},
+	}
+
+	var r = NewReader(strings.NewReader(stream))
+	for i, w := range want {
+		fix, err := r.Next()
+		if err != nil {
+			t.Fatalf("fix %d: %v", i, err)
+		}
+		if fix.Sentence != w.Sentence || math.Abs(fix.Lat-w.Lat) > 1e-9 || math.Abs(fix.Lon-w.Lon) > 1e-9 || !fix.Time.Equal(w.Time) {
+			t.Errorf("fix %d: got %+v, expected %+v", i, fix, w)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("got %v, expected EOF", err)
+	}
+	if r.Skipped != 2 {
+		t.Errorf("skipped %d sentences, expected 2", r.Skipped)
+	}
+}
+
+func TestReaderMidnight(t *testing.T) {
+	// Each epoch sends GGA before RMC, so the GGA after midnight follows the previous day's RMC.
+	var stream = strings.Join([]string{
+		sentence("GPGGA,235959.00,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"),
+		sentence("GPRMC,235959.00,A,4807.038,N,01131.000,E,022.4,084.4,311224,003.1,W"),
+		sentence("GPGGA,000000.00,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"),
+		sentence("GPRMC,000000.00,A,4807.038,N,01131.000,E,022.4,084.4,010125,003.1,W"),
+		sentence("GPGGA,000001.00,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,"),
+	}, "\n")
+	var want = []time.Time{
+		{},
+		time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+
+	var r = NewReader(strings.NewReader(stream))
+	for i, w := range want {
+		fix, err := r.Next()
+		if err != nil {
+			t.Fatalf("fix %d: %v", i, err)
+		}
+		if !fix.Time.Equal(w) {
+			t.Errorf("fix %d %s: got %v, expected %v", i, fix.Sentence, fix.Time, w)
+		}
+	}
+}
+
+const zonesGeoJSON = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Europe/Berlin"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[6, 47], [15, 47], [15, 55], [6, 55], [6, 47]]]}},
+	{"type": "Feature", "properties": {"tzid": "Europe/Warsaw"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[15, 49], [24, 49], [24, 55], [15, 55], [15, 49]]]}}
+]}`
+
+func TestZoneReader(t *testing.T) {
+	var fc tz.Collection
+	if err := json.Unmarshal([]byte(zonesGeoJSON), &fc); err != nil {
+		t.Fatal(err)
+	}
+
+	var stream = strings.Join([]string{
+		sentence("GPRMC,225900,A,5231.000,N,01324.000,E,50.0,90.0,301226,,"),
+		sentence("GPGGA,230000,5231.000,N,01424.000,E,1,08,0.9,40.0,M,,M,,"),
+		sentence("GPGGA,230100,5231.000,N,01524.000,E,1,08,0.9,40.0,M,,M,,"),
+		sentence("GPGGA,230200,5231.000,N,02524.000,E,1,08,0.9,40.0,M,,M,,"),
+		sentence("GPGGA,230300,5231.000,N,01324.000,E,1,08,0.9,40.0,M,,M,,"),
+	}, "\n")
+
+	var want = []struct {
+		TZID  string
+		Local string
+	}{
+		{TZID: "Europe/Berlin", Local: "2026-12-30T23:59:00+01:00"},
+		{TZID: "Europe/Berlin", Local: "2026-12-31T00:00:00+01:00"},
+		{TZID: "Europe/Warsaw", Local: "2026-12-31T00:01:00+01:00"},
+		{TZID: ""},
+		{TZID: "Europe/Berlin", Local: "2026-12-31T00:03:00+01:00"},
+	}
+
+	var z = NewZoneReader(NewReader(strings.NewReader(stream)), &fc)
+	for i, w := range want {
+		zf, err := z.Next()
+		if err != nil {
+			t.Fatalf("fix %d: %v", i, err)
+		}
+		var local string
+		if !zf.Local.IsZero() {
+			local = zf.Local.Format(time.RFC3339)
+		}
+		if zf.TZID != w.TZID || local != w.Local {
+			t.Errorf("fix %d: got %q %q, expected %q %q", i, zf.TZID, local, w.TZID, w.Local)
+		}
+	}
+	if _, err := z.Next(); err != io.EOF {
+		t.Errorf("got %v, expected EOF", err)
+	}
+}
+
+// readZones returns the zone of every fix in the stream.
+func readZones(t *testing.T, fc *tz.Collection, fixes ...string) []string {
+	t.Helper()
+	var stream []string
+	for _, f := range fixes {
+		stream = append(stream, sentence(f))
+	}
+	var (
+		z     = NewZoneReader(NewReader(strings.NewReader(strings.Join(stream, "\n"))), fc)
+		zones []string
+	)
+	for {
+		zf, err := z.Next()
+		if err == io.EOF {
+			return zones
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		zones = append(zones, zf.TZID)
+	}
+}
+
+func TestZoneReaderMatchesTimeZone(t *testing.T) {
+	// America/Denver is a square whose bottom edge has enough vertices for the fast lookup to sample
+	// every fortieth and then every fourth one, with a spike on its top edge that falls between samples.
+	var ring strings.Builder
+	ring.WriteString("[0, 0]")
+	for i := 1; i <= 39997; i++ {
+		fmt.Fprintf(&ring, ", [%v, 0]", 10*float64(i)/39998)
+	}
+	ring.WriteString(", [10, 0], [10, 10], [6, 10], [5.2, 10], [5, 15], [4.8, 10], [4, 10], [0, 10], [0, 0]")
+	var fc tz.Collection
+	if err := json.Unmarshal([]byte(`{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "America/Denver"}, "geometry": {"type": "Polygon",
+		"coordinates": [[`+ring.String()+`]]}}
+]}`), &fc); err != nil {
+		t.Fatal(err)
+	}
+
+	// The spike is outside the sampled polygon, whether or not the previous fix was inside it.
+	var zones = readZones(t, &fc,
+		"GPGGA,120000,1200.000,N,00500.000,E,1,08,0.9,40.0,M,,M,,",
+		"GPGGA,120100,0500.000,N,00500.000,E,1,08,0.9,40.0,M,,M,,",
+		"GPGGA,120200,1200.000,N,00500.000,E,1,08,0.9,40.0,M,,M,,")
+	if f := fc.Features[0]; !f.Contains(12, 5) {
+		t.Fatal("the exact test misses the spike")
+	}
+	for i, lat := range []float64{12, 5, 12} {
+		if want := fc.TimeZone(lat, 5); zones[i] != want {
+			t.Errorf("fix %d: got %q, expected %q as TimeZone returns", i, zones[i], want)
+		}
+	}
+}
+
+func TestZoneReaderCanonicalNames(t *testing.T) {
+	var fc tz.Collection
+	if err := json.Unmarshal([]byte(`{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Europe/Kiev"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[22, 44], [40, 44], [40, 52], [22, 52], [22, 44]]]}}
+]}`), &fc); err != nil {
+		t.Fatal(err)
+	}
+	tz.WithCanonicalNames()(&fc)
+
+	var zones = readZones(t, &fc,
+		"GPGGA,120000,5027.000,N,03031.000,E,1,08,0.9,40.0,M,,M,,",
+		"GPGGA,120100,4628.000,N,03044.000,E,1,08,0.9,40.0,M,,M,,")
+	if got := strings.Join(zones, ","); got != "Europe/Kyiv,Europe/Kyiv" {
+		t.Errorf("got %s", got)
+	}
+}
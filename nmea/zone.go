@@ -0,0 +1,58 @@
+package nmea
+
+import (
+	"github.com/catmullet/tz"
+	"time"
+)
+
+// ZonedFix is a fix with the time zone it lies in. Local is the fix time in that zone, zero when
+// the fix has no time or no zone was found.
+type ZonedFix struct {
+	Fix
+	TZID  string
+	Local time.Time
+}
+
+// ZoneReader resolves the time zone of each fix read from a Reader. Consecutive fixes in a stream
+// are usually in the same zone, so each fix is first tested against the feature the previous one
+// was found in, with the collection's own polygon test, and only looked up across the whole
+// collection when it has left it.
+type ZoneReader struct {
+	r        *Reader
+	fc       *tz.Collection
+	last     *tz.Feature
+	lastTZID string
+}
+
+// NewZoneReader returns a ZoneReader resolving fixes from r in fc.
+func NewZoneReader(r *Reader, fc *tz.Collection) *ZoneReader {
+	return &ZoneReader{r: r, fc: fc}
+}
+
+// Next returns the next fix and its zone, or io.EOF at the end of the stream.
+func (z *ZoneReader) Next() (ZonedFix, error) {
+	fix, err := z.r.Next()
+	if err != nil {
+		return ZonedFix{}, err
+	}
+	var zf = ZonedFix{Fix: fix, TZID: z.timeZone(fix.Lat, fix.Lon)}
+	if zf.TZID == "" || fix.Time.IsZero() {
+		return zf, nil
+	}
+
+	loc, err := tz.LoadLocation(zf.TZID)
+	if err != nil {
+		return ZonedFix{}, err
+	}
+	zf.Local = fix.Time.In(loc)
+	return zf, nil
+}
+
+func (z *ZoneReader) timeZone(lat, lon float64) string {
+	if z.last != nil && z.fc.FeatureContains(z.last, lat, lon) {
+		return z.lastTZID
+	}
+	// A nautical fallback zone has no feature, leaving nothing to test the next fix against.
+	z.lastTZID, z.last = z.fc.FeatureAt(lat, lon)
+	return z.lastTZID
+}
@@ -0,0 +1,212 @@
+package tz
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// boundaryEpsilon is the distance in degrees within which a point is taken to lie on a polygon edge.
+	boundaryEpsilon = 1e-9
+	// shareEpsilon is the share of the query area below which an intersection is taken as rounding error,
+	// left over where a zone's polygon and its hole cancel out.
+	shareEpsilon = 1e-9
+)
+
+// ZoneShare is the part of a query area covered by one time zone.
+type ZoneShare struct {
+	TZID string
+	// Area is the area of the intersection on the sphere in square meters.
+	Area float64
+	// Share is Area as a fraction of the whole query area.
+	Share float64
+}
+
+// ZonesInPolygon returns the time zones covering a GeoJSON Polygon or MultiPolygon geometry, see ZonesInGeometry.
+func (fc Collection) ZonesInPolygon(geojson []byte) ([]ZoneShare, error) {
+	var g Geometry
+	if err := json.Unmarshal(geojson, &g); err != nil {
+		return nil, err
+	}
+	return fc.ZonesInGeometry(g)
+}
+
+// ZonesInGeometry returns every time zone whose polygons intersect the geometry together with the area
+// of the intersection, largest first. Holes are cut out of both the geometry and the time zones.
+func (fc Collection) ZonesInGeometry(g Geometry) ([]ZoneShare, error) {
+	var total = g.Area()
+	if len(g.Coordinates) == 0 || total == 0 {
+		return nil, fmt.Errorf("geometry has no polygon area")
+	}
+
+	var (
+		query  = signedRings(g.Coordinates)
+		byID   = make(map[string]float64)
+		shares []ZoneShare
+	)
+	for _, f := range fc.Features {
+		if f == nil || !boundsOverlap(f.Geometry.MinPoint, f.Geometry.MaxPoint, g.MinPoint, g.MaxPoint) {
+			continue
+		}
+		// The intersection of two polygons with holes adds and removes the intersections of their rings.
+		for _, zone := range signedRings(f.Geometry.Coordinates) {
+			for _, q := range query {
+				if boundsOverlap(zone.MinPoint, zone.MaxPoint, q.MinPoint, q.MaxPoint) {
+					byID[fc.featureTimeZone(f)] += zone.sign * q.sign * intersectionArea(zone.Coordinates, q.Coordinates)
+				}
+			}
+		}
+	}
+
+	for tzid, area := range byID {
+		if area > total*shareEpsilon {
+			shares = append(shares, ZoneShare{TZID: tzid, Area: area, Share: area / total})
+		}
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Area != shares[j].Area {
+			return shares[i].Area > shares[j].Area
+		}
+		return shares[i].TZID < shares[j].TZID
+	})
+	return shares, nil
+}
+
+// signedRing is a ring of a polygon, adding to its area when sign is 1 and cut out as a hole when it is -1.
+type signedRing struct {
+	Coordinates
+	sign float64
+}
+
+// signedRings returns the outer rings and holes of the polygons, every ring closed and winding
+// counterclockwise.
+func signedRings(coords []Coordinates) []signedRing {
+	var rings []signedRing
+	for _, c := range coords {
+		rings = append(rings, signedRing{Coordinates: Coordinates{Polygon: orientedRing(c.Polygon),
+			MinPoint: c.MinPoint, MaxPoint: c.MaxPoint}, sign: 1})
+		for _, hole := range c.Holes {
+			r := signedRing{Coordinates: Coordinates{Polygon: orientedRing(hole), MaxPoint: Point{Lon: -180.0,
+				Lat: -90.0}, MinPoint: Point{Lon: 180.0, Lat: 90.0}}, sign: -1}
+			for _, p := range hole {
+				updateMaxMin(&r.MaxPoint, &r.MinPoint, p.Lat, p.Lon)
+			}
+			rings = append(rings, r)
+		}
+	}
+	return rings
+}
+
+// orientedRing returns the ring closed and winding counterclockwise.
+func orientedRing(ring []Point) []Point {
+	if n := len(ring); n > 0 && ring[0] != ring[n-1] {
+		ring = append(ring[:n:n], ring[0])
+	}
+	if planarArea(ring) < 0 {
+		reversed := make([]Point, len(ring))
+		for j, p := range ring {
+			reversed[len(ring)-1-j] = p
+		}
+		ring = reversed
+	}
+	return ring
+}
+
+func planarArea(ring []Point) float64 {
+	var area float64
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		area += ring[j].Lon*ring[i].Lat - ring[i].Lon*ring[j].Lat
+	}
+	return area / 2
+}
+
+// intersectionArea returns the area on the sphere shared by two counterclockwise rings.
+// The boundary of the intersection is made of the parts of each ring's edges that lie inside the other,
+// so integrating edgeArea along just those parts gives its area without building the clipped polygon.
+// Edges the rings share count once, and only where both interiors lie on the same side of them.
+func intersectionArea(a, b Coordinates) float64 {
+	var area = boundaryInside(a, b, true) + boundaryInside(b, a, false)
+	return math.Abs(area)
+}
+
+// boundaryInside sums edgeArea over the parts of ring's edges inside other. With shared set, parts lying
+// on an edge of other running the same direction are included as well.
+func boundaryInside(ring, other Coordinates, shared bool) float64 {
+	var area float64
+	for i := 1; i < len(ring.Polygon); i++ {
+		a, b := ring.Polygon[i-1], ring.Polygon[i]
+		if !boundsOverlap(Point{Lon: math.Min(a.Lon, b.Lon), Lat: math.Min(a.Lat, b.Lat)},
+			Point{Lon: math.Max(a.Lon, b.Lon), Lat: math.Max(a.Lat, b.Lat)}, other.MinPoint, other.MaxPoint) {
+			continue
+		}
+
+		var ts = splitEdge(a, b, other.Polygon)
+		for j := 1; j < len(ts); j++ {
+			mid := lerp(a, b, (ts[j-1]+ts[j])/2)
+			if on, same := onEdge(mid, a, b, other.Polygon); on {
+				if !shared || !same {
+					continue
+				}
+			} else if windingNumber(mid.Lat, mid.Lon, other.Polygon, 1) == 0 {
+				continue
+			}
+			area += edgeArea(lerp(a, b, ts[j-1]), lerp(a, b, ts[j]))
+		}
+	}
+	return area
+}
+
+// splitEdge returns the sorted parameters along a→b, from 0 to 1, where it meets the edges of ring.
+func splitEdge(a, b Point, ring []Point) []float64 {
+	var (
+		ts     = []float64{0, 1}
+		dx, dy = b.Lon - a.Lon, b.Lat - a.Lat
+		length = dx*dx + dy*dy
+	)
+	if length == 0 {
+		return ts
+	}
+	for i := 1; i < len(ring); i++ {
+		c, d := ring[i-1], ring[i]
+		ex, ey := d.Lon-c.Lon, d.Lat-c.Lat
+		cx, cy := c.Lon-a.Lon, c.Lat-a.Lat
+		denom := dx*ey - dy*ex
+		if math.Abs(denom) > 1e-18 {
+			t := (cx*ey - cy*ex) / denom
+			u := (cx*dy - cy*dx) / denom
+			if t > 0 && t < 1 && u >= 0 && u <= 1 {
+				ts = append(ts, t)
+			}
+			continue
+		}
+		// Parallel edges only meet when collinear, splitting a→b where the other edge starts and ends.
+		if math.Abs(cx*dy-cy*dx) > boundaryEpsilon*math.Sqrt(length) {
+			continue
+		}
+		for _, p := range []Point{c, d} {
+			if t := ((p.Lon-a.Lon)*dx + (p.Lat-a.Lat)*dy) / length; t > 0 && t < 1 {
+				ts = append(ts, t)
+			}
+		}
+	}
+	sort.Float64s(ts)
+	return ts
+}
+
+// onEdge reports whether p lies on an edge of ring, and if so whether that edge runs the same way as a→b.
+func onEdge(p, a, b Point, ring []Point) (on bool, same bool) {
+	for i := 1; i < len(ring); i++ {
+		c, d := ring[i-1], ring[i]
+		if segmentDistance(p, c, d) > boundaryEpsilon {
+			continue
+		}
+		return true, (b.Lon-a.Lon)*(d.Lon-c.Lon)+(b.Lat-a.Lat)*(d.Lat-c.Lat) > 0
+	}
+	return false, false
+}
+
+func lerp(a, b Point, t float64) Point {
+	return Point{Lon: a.Lon + (b.Lon-a.Lon)*t, Lat: a.Lat + (b.Lat-a.Lat)*t}
+}
@@ -0,0 +1,188 @@
+package tz
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestZonesInPolygon(t *testing.T) {
+	var (
+		fc   = newTestCollection(t, featureGeoJSON)
+		cell = func(minLon, minLat, maxLon, maxLat float64) float64 {
+			return Coordinates{Polygon: []Point{{minLon, minLat}, {maxLon, minLat}, {maxLon, maxLat},
+				{minLon, maxLat}, {minLon, minLat}}}.Area()
+		}
+	)
+	fc.Features = append(fc.Features, newTestCollection(t, polarGeoJSON).Features...)
+
+	var tests = []struct {
+		Name    string
+		GeoJSON string
+		Shares  map[string]float64
+		Covered bool
+	}{
+		{
+			Name:    "half in one arm of the U",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[-111, 40], [-109.5, 40], [-109.5, 41], [-111, 41], [-111, 40]]]}`,
+			Shares:  map[string]float64{"America/Denver": cell(-110, 40, -109.5, 41)},
+		},
+		{
+			Name:    "clockwise and spanning the gap",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[-111, 40], [-111, 41], [-103, 41], [-103, 40], [-111, 40]]]}`,
+			Shares:  map[string]float64{"America/Denver": cell(-110, 40, -109, 41) + cell(-105, 40, -104, 41)},
+		},
+		{
+			Name: "identical to the zone",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[-110, 35], [-104, 35], [-104, 45], [-105, 45], [-105, 36],
+				[-109, 36], [-109, 45], [-110, 45], [-110, 35]]]}`,
+			Shares:  map[string]float64{"America/Denver": cell(-110, 35, -104, 36) + cell(-110, 36, -109, 45) + cell(-105, 36, -104, 45)},
+			Covered: true,
+		},
+		{
+			Name:    "sharing an edge from outside",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[-104, 38], [-100, 38], [-100, 40], [-104, 40], [-104, 38]]]}`,
+			Shares:  nil,
+		},
+		{
+			Name: "multipolygon over both sides of the antimeridian",
+			GeoJSON: `{"type": "MultiPolygon", "coordinates": [[[[179, -18], [180, -18], [180, -17], [179, -17], [179, -18]]],
+				[[[-180, -17], [-179.5, -17], [-179.5, -15], [-180, -15], [-180, -17]]]]}`,
+			Shares: map[string]float64{"Pacific/Fiji": cell(179, -18, 180, -17) + cell(-180, -17, -179.5, -16)},
+		},
+		{
+			Name:    "across adjacent sectors",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[20, -80], [30, -80], [30, -75], [20, -75], [20, -80]]]}`,
+			Shares:  map[string]float64{"Antarctica/Troll": cell(20, -80, 25, -75), "Antarctica/Vostok": cell(25, -80, 30, -75)},
+			Covered: true,
+		},
+	}
+	for _, tt := range tests {
+		shares, err := fc.ZonesInPolygon([]byte(tt.GeoJSON))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.Name, err)
+		}
+		if len(shares) != len(tt.Shares) {
+			t.Errorf("%s: got %+v, expected %v", tt.Name, shares, tt.Shares)
+			continue
+		}
+		var total float64
+		for _, s := range shares {
+			if want := tt.Shares[s.TZID]; math.Abs(s.Area-want) > want*1e-9 {
+				t.Errorf("%s: %s area %v, expected %v", tt.Name, s.TZID, s.Area, want)
+			}
+			total += s.Share
+		}
+		if tt.Covered && math.Abs(total-1) > 1e-9 {
+			t.Errorf("%s: shares sum to %v", tt.Name, total)
+		}
+	}
+}
+
+func TestZonesInPolygonHoles(t *testing.T) {
+	var (
+		fc   = newTestCollection(t, holedGeoJSON)
+		cell = func(minLon, minLat, maxLon, maxLat float64) float64 {
+			return Coordinates{Polygon: []Point{{minLon, minLat}, {maxLon, minLat}, {maxLon, maxLat},
+				{minLon, maxLat}, {minLon, minLat}}}.Area()
+		}
+	)
+	var tests = []struct {
+		Name    string
+		GeoJSON string
+		Shares  map[string]float64
+	}{
+		{
+			Name:    "inside the enclave",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[25, -30], [27, -30], [27, -28], [25, -28], [25, -30]]]}`,
+			Shares:  map[string]float64{"Africa/Maseru": cell(25, -30, 27, -28)},
+		},
+		{
+			Name:    "across the edge of the hole",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[22, -30], [26, -30], [26, -28], [22, -28], [22, -30]]]}`,
+			Shares:  map[string]float64{"Africa/Johannesburg": cell(22, -30, 24, -28), "Africa/Maseru": cell(24, -30, 26, -28)},
+		},
+		{
+			Name: "query with the enclave as its hole",
+			GeoJSON: `{"type": "Polygon", "coordinates": [[[21, -33], [31, -33], [31, -25], [21, -25], [21, -33]],
+				[[24, -31], [28, -31], [28, -27], [24, -27], [24, -31]]]}`,
+			Shares: map[string]float64{"Africa/Johannesburg": cell(21, -33, 31, -25) - cell(24, -31, 28, -27)},
+		},
+	}
+	for _, tt := range tests {
+		shares, err := fc.ZonesInPolygon([]byte(tt.GeoJSON))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.Name, err)
+		}
+		if len(shares) != len(tt.Shares) {
+			t.Errorf("%s: got %+v, expected %v", tt.Name, shares, tt.Shares)
+			continue
+		}
+		var total float64
+		for _, s := range shares {
+			if want := tt.Shares[s.TZID]; math.Abs(s.Area-want) > want*1e-9 {
+				t.Errorf("%s: %s area %v, expected %v", tt.Name, s.TZID, s.Area, want)
+			}
+			total += s.Share
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("%s: shares sum to %v", tt.Name, total)
+		}
+	}
+}
+
+func TestZonesInPolygonErrors(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	for _, geojson := range []string{
+		`{"type": "Point", "coordinates": [0, 0]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 1], [0, 0]]]}`,
+		`not json`,
+		`{"type": "Polygon"}`,
+		`{"type": "Polygon", "coordinates": []}`,
+		`{"type": "Polygon", "coordinates": [[]]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 1]]]}`,
+		`{"type": "Polygon", "coordinates": [[[0]]]}`,
+		`{"type": "Polygon", "coordinates": [[[-110, 40], [-109], [-109, 41], [-110, 40]]]}`,
+		`{"type": "MultiPolygon", "coordinates": []}`,
+		`{"type": "MultiPolygon", "coordinates": [[]]}`,
+	} {
+		if _, err := fc.ZonesInPolygon([]byte(geojson)); err == nil {
+			t.Errorf("%s: expected error", geojson)
+		}
+	}
+}
+
+func TestZonesInPolygonConcurrent(t *testing.T) {
+	var (
+		fc      = newTestCollection(t, featureGeoJSON)
+		queries = []string{
+			`{"type": "Polygon", "coordinates": [[[-111, 40], [-109.5, 40], [-109.5, 41], [-111, 41], [-111, 40]]]}`,
+			`{"type": "Polygon", "coordinates": [[[178, -18], [179, -18], [179, -17], [178, -17], [178, -18]]]}`,
+			`{"type": "Polygon"}`,
+		}
+		wg sync.WaitGroup
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			q := queries[i%len(queries)]
+			shares, err := fc.ZonesInPolygon([]byte(q))
+			switch i % len(queries) {
+			case 0:
+				if err != nil || len(shares) != 1 || shares[0].TZID != "America/Denver" {
+					t.Errorf("%s: got %+v, %v", q, shares, err)
+				}
+			case 1:
+				if err != nil || len(shares) != 1 || shares[0].TZID != "Pacific/Fiji" {
+					t.Errorf("%s: got %+v, %v", q, shares, err)
+				}
+			default:
+				if err == nil {
+					t.Errorf("%s: got %+v, expected error", q, shares)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,79 @@
+package tz
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	plusCodeAlphabet  = "23456789CFGHJMPQRVWX"
+	plusCodeSeparator = 8
+	plusCodePairs     = 10
+	plusCodeMaxDigits = 15
+	plusCodeGridRows  = 5
+	plusCodeGridCols  = 4
+)
+
+// DecodePlusCode returns the bounds of the cell of a full Open Location Code such as 8FVC9G8F+6X.
+// Short codes, which need a reference location to recover their missing digits, are rejected.
+func DecodePlusCode(code string) (minPoint, maxPoint Point, err error) {
+	var invalid = fmt.Errorf("invalid plus code %q", code)
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	sep := strings.IndexByte(code, '+')
+	if sep < 0 || sep != strings.LastIndexByte(code, '+') || sep%2 == 1 || sep > plusCodeSeparator {
+		return Point{}, Point{}, invalid
+	}
+	if sep < plusCodeSeparator {
+		return Point{}, Point{}, fmt.Errorf("short plus code %q needs a reference location", code)
+	}
+	if len(code)-sep-1 == 1 {
+		return Point{}, Point{}, invalid
+	}
+
+	var digits = code[:sep] + code[sep+1:]
+	if pad := strings.IndexByte(digits, '0'); pad >= 0 {
+		// Padding fills whole pairs up to the separator and cannot be followed by more digits.
+		if pad == 0 || pad%2 == 1 || sep+1 < len(code) || strings.Trim(code[pad:sep], "0") != "" {
+			return Point{}, Point{}, invalid
+		}
+		digits = digits[:pad]
+	}
+	if len(digits) > plusCodeMaxDigits {
+		digits = digits[:plusCodeMaxDigits]
+	}
+
+	var (
+		lat, lon           = -90.0, -180.0
+		latPlace, lonPlace = 400.0, 400.0
+	)
+	for i := 0; i < len(digits); i++ {
+		v := strings.IndexByte(plusCodeAlphabet, digits[i])
+		if v < 0 {
+			return Point{}, Point{}, invalid
+		}
+		if i < plusCodePairs {
+			if i%2 == 0 {
+				latPlace /= 20
+				lat += float64(v) * latPlace
+			} else {
+				lonPlace /= 20
+				lon += float64(v) * lonPlace
+			}
+			continue
+		}
+		latPlace /= plusCodeGridRows
+		lonPlace /= plusCodeGridCols
+		lat += float64(v/plusCodeGridCols) * latPlace
+		lon += float64(v%plusCodeGridCols) * lonPlace
+	}
+	if lat >= 90 || lon >= 180 {
+		return Point{}, Point{}, invalid
+	}
+
+	minPoint, maxPoint = Point{Lon: lon, Lat: lat}, Point{Lon: lon + lonPlace, Lat: lat + latPlace}
+	if maxPoint.Lat > 90 {
+		maxPoint.Lat = 90
+	}
+	return minPoint, maxPoint, nil
+}
@@ -0,0 +1,42 @@
+package tz
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecodePlusCode(t *testing.T) {
+	var tests = []struct {
+		Code string
+		Min  Point
+		Max  Point
+	}{
+		{Code: "8FVC9G8F+6X", Min: Point{8.524875, 47.3655}, Max: Point{8.525, 47.365625}},
+		{Code: "8fvc9g8f+6xq", Min: Point{8.52496875, 47.365575}, Max: Point{8.525, 47.3656}},
+		{Code: "7FG49QCJ+2VX", Min: Point{2.78221875, 20.3701}, Max: Point{2.78225, 20.370125}},
+		{Code: "8FVC0000+", Min: Point{8, 47}, Max: Point{9, 48}},
+		{Code: "8F000000+", Min: Point{0, 30}, Max: Point{20, 50}},
+		{Code: "CFX30000+", Min: Point{1, 89}, Max: Point{2, 90}},
+	}
+	for _, test := range tests {
+		min, max, err := DecodePlusCode(test.Code)
+		if err != nil {
+			t.Errorf("%s: %v", test.Code, err)
+			continue
+		}
+		if !pointNear(min, test.Min) || !pointNear(max, test.Max) {
+			t.Errorf("%s: got %v %v, expected %v %v", test.Code, min, max, test.Min, test.Max)
+		}
+	}
+
+	for _, code := range []string{"", "8FVC9G8F", "9G8F+6X", "8FVC9G8F+6", "8FVC9G8F+6X+", "8FVC0000+6X",
+		"8FV00000+", "8FVC00G0+", "8FVC9G8F+6A", "WFVC9G8F+6X", "8FVC9G8+F6X"} {
+		if _, _, err := DecodePlusCode(code); err == nil {
+			t.Errorf("%q: expected error", code)
+		}
+	}
+}
+
+func pointNear(a, b Point) bool {
+	return math.Abs(a.Lat-b.Lat) < 1e-9 && math.Abs(a.Lon-b.Lon) < 1e-9
+}
@@ -0,0 +1,37 @@
+package tz
+
+import "math"
+
+const (
+	// NorthPoleTimeZone is returned for latitude 90. Every meridian meets at the pole, so rather than
+	// let whichever polygon happens to be tested first win, the pole is pinned to UTC.
+	NorthPoleTimeZone = "Etc/GMT"
+	// SouthPoleTimeZone is returned for latitude -90. It is the zone kept by Amundsen–Scott South Pole
+	// Station, which follows New Zealand time like McMurdo.
+	SouthPoleTimeZone = "Antarctica/McMurdo"
+
+	// poleEpsilon is how close in degrees a latitude must be to ±90 to be treated as the pole itself.
+	poleEpsilon = 1e-9
+)
+
+// normalizeCoordinates validates lat and wraps lon into [-180, 180].
+// It reports false for NaN or infinite values and for latitudes beyond the poles.
+func normalizeCoordinates(lat, lon float64) (float64, float64, bool) {
+	if math.IsNaN(lat) || math.IsNaN(lon) || math.IsInf(lon, 0) || lat < -90 || lat > 90 {
+		return lat, lon, false
+	}
+	return lat, normalizeLon(lon), true
+}
+
+// poleTimeZone returns the fixed zone for a latitude at either pole, where longitude and
+// therefore the planar polygon test carry no meaning.
+func poleTimeZone(lat float64) (string, bool) {
+	switch {
+	case lat >= 90-poleEpsilon:
+		return NorthPoleTimeZone, true
+	case lat <= -90+poleEpsilon:
+		return SouthPoleTimeZone, true
+	default:
+		return "", false
+	}
+}
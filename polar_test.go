@@ -0,0 +1,107 @@
+package tz
+
+import (
+	"math"
+	"testing"
+)
+
+// polarGeoJSON has Antarctic sectors running from the coast to the pole, with McMurdo split at the
+// antimeridian, and Arctic ocean wedges meeting at the north pole.
+const polarGeoJSON = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Antarctica/Troll"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-20, -70], [25, -70], [25, -90], [-20, -90], [-20, -70]]]}},
+	{"type": "Feature", "properties": {"tzid": "Antarctica/Vostok"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[25, -66], [110, -66], [110, -90], [25, -90], [25, -66]]]}},
+	{"type": "Feature", "properties": {"tzid": "Antarctica/Casey"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[110, -66], [160, -66], [160, -90], [110, -90], [110, -66]]]}},
+	{"type": "Feature", "properties": {"tzid": "Antarctica/McMurdo"}, "geometry": {"type": "MultiPolygon",
+		"coordinates": [[[[160, -72], [180, -72], [180, -90], [160, -90], [160, -72]]],
+			[[[-180, -72], [-150, -72], [-150, -90], [-180, -90], [-180, -72]]]]}},
+	{"type": "Feature", "properties": {"tzid": "Antarctica/Rothera"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-150, -66], [-20, -66], [-20, -90], [-150, -90], [-150, -66]]]}},
+	{"type": "Feature", "properties": {"tzid": "Etc/GMT"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-7.5, 80], [7.5, 80], [7.5, 90], [-7.5, 90], [-7.5, 80]]]}},
+	{"type": "Feature", "properties": {"tzid": "Etc/GMT-1"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[7.5, 80], [22.5, 80], [22.5, 90], [7.5, 90], [7.5, 80]]]}},
+	{"type": "Feature", "properties": {"tzid": "Etc/GMT+1"}, "geometry": {"type": "Polygon",
+		"coordinates": [[[-22.5, 80], [-7.5, 80], [-7.5, 90], [-22.5, 90], [-22.5, 80]]]}}
+]}`
+
+func TestPolarTimeZone(t *testing.T) {
+	var fc = newTestCollection(t, polarGeoJSON)
+	var tests = []struct {
+		Lat  float64
+		Lon  float64
+		TZID string
+	}{
+		{Lat: -90, Lon: 0, TZID: SouthPoleTimeZone},
+		{Lat: -90, Lon: 25, TZID: SouthPoleTimeZone},
+		{Lat: -90, Lon: -180, TZID: SouthPoleTimeZone},
+		{Lat: -90, Lon: 1234, TZID: SouthPoleTimeZone},
+		{Lat: -89.9999, Lon: 0, TZID: "Antarctica/Troll"},
+		{Lat: -89.9999, Lon: 60, TZID: "Antarctica/Vostok"},
+		{Lat: -89.9999, Lon: 135, TZID: "Antarctica/Casey"},
+		{Lat: -89.9999, Lon: 179.9999, TZID: "Antarctica/McMurdo"},
+		{Lat: -89.9999, Lon: -179.9999, TZID: "Antarctica/McMurdo"},
+		{Lat: -89.9999, Lon: 540, TZID: "Antarctica/McMurdo"},
+		{Lat: -77.85, Lon: 166.67, TZID: "Antarctica/McMurdo"},
+		{Lat: -78.46, Lon: 106.84, TZID: "Antarctica/Vostok"},
+		{Lat: -67.57, Lon: -68.13, TZID: "Antarctica/Rothera"},
+		{Lat: -72.01, Lon: 2.53, TZID: "Antarctica/Troll"},
+		{Lat: 90, Lon: 0, TZID: NorthPoleTimeZone},
+		{Lat: 90, Lon: -135, TZID: NorthPoleTimeZone},
+		{Lat: 89.9999, Lon: 15, TZID: "Etc/GMT-1"},
+		{Lat: 89.9999, Lon: -15, TZID: "Etc/GMT+1"},
+		{Lat: 90.0001, Lon: 0, TZID: ""},
+		{Lat: -90.0001, Lon: 0, TZID: ""},
+		{Lat: math.NaN(), Lon: 0, TZID: ""},
+		{Lat: 0, Lon: math.Inf(1), TZID: ""},
+	}
+	for _, tt := range tests {
+		if tzid := fc.TimeZone(tt.Lat, tt.Lon); tzid != tt.TZID {
+			t.Errorf("lat %v lon %v: got %q, expected %q", tt.Lat, tt.Lon, tzid, tt.TZID)
+		}
+	}
+}
+
+func TestPolarTimeZonePole(t *testing.T) {
+	var tests = []struct {
+		Name string
+		Lat  float64
+		Lon  float64
+		TZID string
+	}{
+		// Every Antarctic sector ring runs along -90, so the pole is inside all of them.
+		{Name: "south pole", Lat: -90, Lon: 0, TZID: SouthPoleTimeZone},
+		{Name: "south pole on a shared edge", Lat: -90, Lon: 25, TZID: SouthPoleTimeZone},
+		{Name: "south pole at the antimeridian", Lat: -90, Lon: 180, TZID: SouthPoleTimeZone},
+		{Name: "within epsilon of the south pole", Lat: -90 + poleEpsilon/2, Lon: 60, TZID: SouthPoleTimeZone},
+		{Name: "at epsilon of the south pole", Lat: -90 + poleEpsilon, Lon: 60, TZID: SouthPoleTimeZone},
+		{Name: "just beyond epsilon of the south pole", Lat: -90 + 10*poleEpsilon, Lon: 60, TZID: "Antarctica/Vostok"},
+		{Name: "north pole", Lat: 90, Lon: 15, TZID: NorthPoleTimeZone},
+		{Name: "within epsilon of the north pole", Lat: 90 - poleEpsilon/2, Lon: 15, TZID: NorthPoleTimeZone},
+		{Name: "just beyond epsilon of the north pole", Lat: 90 - 10*poleEpsilon, Lon: 15, TZID: "Etc/GMT-1"},
+		{Name: "just beyond epsilon west of the north pole", Lat: 90 - 10*poleEpsilon, Lon: -15, TZID: "Etc/GMT+1"},
+	}
+
+	// The answer at the pole must not depend on which of the rings touching it is tested first.
+	var fc = newTestCollection(t, polarGeoJSON)
+	for shift := 0; shift < len(fc.Features); shift++ {
+		var rotated = newTestCollection(t, polarGeoJSON)
+		rotated.Features = append(append([]*Feature(nil), fc.Features[shift:]...), fc.Features[:shift]...)
+		for _, tt := range tests {
+			if tzid := rotated.TimeZone(tt.Lat, tt.Lon); tzid != tt.TZID {
+				t.Errorf("%s, features rotated by %d: got %q, expected %q", tt.Name, shift, tzid, tt.TZID)
+			}
+		}
+	}
+}
+
+func TestPolarLocation(t *testing.T) {
+	var fc = newTestCollection(t, polarGeoJSON)
+	for _, lat := range []float64{-90, 90} {
+		if _, err := fc.Location(lat, 0); err != nil {
+			t.Errorf("lat %v: %v", lat, err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package tz
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// maxInitialCells bounds how many cells cover the longer side of a ring's bounding box when the search starts.
+const maxInitialCells = 64
+
+// poleOfInaccessibility finds the point inside the polygon farthest from the edges of its outer ring and
+// holes using the polylabel quadtree search, working in planar longitude and latitude. The result is
+// always strictly inside a polygon with non-zero area: if the search ends on a point that is not, the
+// midpoint of the widest interior span of the polygon's middle parallel is returned instead.
+func poleOfInaccessibility(c Coordinates) Point {
+	var (
+		ring     = c.Polygon
+		minPoint = c.MinPoint
+		maxPoint = c.MaxPoint
+		width    = maxPoint.Lon - minPoint.Lon
+		height   = maxPoint.Lat - minPoint.Lat
+		cellSize = math.Min(width, height)
+	)
+	if len(ring) < 3 || cellSize == 0 {
+		return minPoint
+	}
+	// Keep long thin rings from being covered by an enormous number of initial cells.
+	cellSize = math.Max(cellSize, math.Max(width, height)/maxInitialCells)
+
+	var (
+		precision = math.Max(width, height) / 1000
+		h         = cellSize / 2
+		cells     = &cellQueue{}
+		best      = newCell(c, centroid(ring), 0)
+	)
+	if mid := newCell(c, Point{Lon: minPoint.Lon + width/2, Lat: minPoint.Lat + height/2}, 0); mid.d > best.d {
+		best = mid
+	}
+	for x := minPoint.Lon; x < maxPoint.Lon; x += cellSize {
+		for y := minPoint.Lat; y < maxPoint.Lat; y += cellSize {
+			heap.Push(cells, newCell(c, Point{Lon: x + h, Lat: y + h}, h))
+		}
+	}
+
+	for cells.Len() > 0 {
+		next := heap.Pop(cells).(cell)
+		if next.d > best.d {
+			best = next
+		}
+		if next.max-best.d <= precision {
+			continue
+		}
+		h = next.h / 2
+		heap.Push(cells, newCell(c, Point{Lon: next.center.Lon - h, Lat: next.center.Lat - h}, h))
+		heap.Push(cells, newCell(c, Point{Lon: next.center.Lon + h, Lat: next.center.Lat - h}, h))
+		heap.Push(cells, newCell(c, Point{Lon: next.center.Lon - h, Lat: next.center.Lat + h}, h))
+		heap.Push(cells, newCell(c, Point{Lon: next.center.Lon + h, Lat: next.center.Lat + h}, h))
+	}
+
+	if best.d > 0 {
+		return best.center
+	}
+	return scanlineInteriorPoint(append([][]Point{ring}, c.Holes...), minPoint.Lat+height/2)
+}
+
+type cell struct {
+	center Point
+	h      float64
+	d      float64
+	max    float64
+}
+
+func newCell(c Coordinates, center Point, h float64) cell {
+	var d = pointToPolygonDistance(center, c)
+	return cell{center: center, h: h, d: d, max: d + h*math.Sqrt2}
+}
+
+// cellQueue is a max heap of cells ordered by the best distance they could contain.
+type cellQueue []cell
+
+func (q cellQueue) Len() int            { return len(q) }
+func (q cellQueue) Less(i, j int) bool  { return q[i].max > q[j].max }
+func (q cellQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cellQueue) Push(x interface{}) { *q = append(*q, x.(cell)) }
+func (q *cellQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// pointToPolygonDistance returns the distance from p to the nearest edge of the outer ring or a hole,
+// negative when p is outside the polygon or inside a hole.
+func pointToPolygonDistance(p Point, c Coordinates) float64 {
+	var (
+		inside  = c.contains(p, 1)
+		minDist = math.Inf(1)
+	)
+	for _, ring := range append([][]Point{c.Polygon}, c.Holes...) {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			minDist = math.Min(minDist, segmentDistance(p, ring[j], ring[i]))
+		}
+	}
+	if !inside {
+		return -minDist
+	}
+	return minDist
+}
+
+func segmentDistance(p, a, b Point) float64 {
+	var (
+		x, y   = a.Lon, a.Lat
+		dx, dy = b.Lon - x, b.Lat - y
+	)
+	if dx != 0 || dy != 0 {
+		t := ((p.Lon-x)*dx + (p.Lat-y)*dy) / (dx*dx + dy*dy)
+		if t > 1 {
+			x, y = b.Lon, b.Lat
+		} else if t > 0 {
+			x, y = x+dx*t, y+dy*t
+		}
+	}
+	return math.Hypot(p.Lon-x, p.Lat-y)
+}
+
+// centroid returns the planar centroid of the ring, or its first point when the ring has no area.
+func centroid(ring []Point) Point {
+	var x, y, area float64
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		f := a.Lon*b.Lat - b.Lon*a.Lat
+		x += (a.Lon + b.Lon) * f
+		y += (a.Lat + b.Lat) * f
+		area += f * 3
+	}
+	if area == 0 {
+		return ring[0]
+	}
+	return Point{Lon: x / area, Lat: y / area}
+}
+
+// scanlineInteriorPoint returns the midpoint of the widest span of the parallel at lat that lies
+// inside the rings, the outer ring first and then its holes. lat is nudged off any vertex so every
+// crossing is a proper one.
+func scanlineInteriorPoint(rings [][]Point, lat float64) Point {
+	for _, ring := range rings {
+		for _, p := range ring {
+			if p.Lat == lat {
+				return scanlineInteriorPoint(rings, math.Nextafter(lat, math.Inf(1)))
+			}
+		}
+	}
+
+	var crossings []float64
+	for _, ring := range rings {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[j], ring[i]
+			if (a.Lat < lat) != (b.Lat < lat) {
+				crossings = append(crossings, a.Lon+(lat-a.Lat)/(b.Lat-a.Lat)*(b.Lon-a.Lon))
+			}
+		}
+	}
+	sort.Float64s(crossings)
+
+	var best = Point{Lon: rings[0][0].Lon, Lat: rings[0][0].Lat}
+	var widest = -1.0
+	for i := 0; i+1 < len(crossings); i += 2 {
+		if w := crossings[i+1] - crossings[i]; w > widest {
+			widest = w
+			best = Point{Lon: crossings[i] + w/2, Lat: lat}
+		}
+	}
+	return best
+}
@@ -0,0 +1,165 @@
+package tz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// posixRuleYears is the number of years over which a zone's transitions must follow its POSIX rule.
+	posixRuleYears = 10
+	// posixDefaultTime is the transition time assumed by POSIX when a rule gives none.
+	posixDefaultTime = 2 * 3600
+)
+
+// posixDayShifts are the days by which a transition date is moved, with the time moved the other way,
+// to find a Mm.w.d rule. Israel, for instance, changes on the Friday before the last Sunday of March,
+// which is written as the fourth Thursday at 26:00.
+var posixDayShifts = []int{0, 1, -1, 2, -2, 3, -3}
+
+// PosixTZ returns the POSIX TZ string, such as CST6CDT,M3.2.0,M11.1.0, for the rules of the time zone at
+// a coordinate from the year of the instant at. The rule is derived from the zone's transitions over ten
+// years and an error is returned when they do not follow a single rule, or when it cannot be expressed.
+func (fc Collection) PosixTZ(lat, lon float64, at time.Time) (string, error) {
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return "", err
+	}
+	loc, err := LoadLocation(tzid)
+	if err != nil {
+		return "", err
+	}
+	return posixTZ(loc, at)
+}
+
+func posixTZ(loc *time.Location, at time.Time) (string, error) {
+	var (
+		year = at.In(loc).Year()
+		trs  = transitions(loc, time.Date(year, time.January, 1, 0, 0, 0, 0, loc),
+			time.Date(year+posixRuleYears, time.January, 1, 0, 0, 0, 0, loc))
+	)
+	if len(trs) == 0 {
+		name, offset := at.In(loc).Zone()
+		return posixName(name) + posixOffset(offset), nil
+	}
+	if len(trs) != 2*posixRuleYears {
+		return "", fmt.Errorf("no stable rule for %s: %d transitions in %d years", loc, len(trs), posixRuleYears)
+	}
+
+	// Split the transitions into those starting and those ending daylight saving time, which must alternate
+	// between the same two offsets and abbreviations.
+	var starts, ends []Transition
+	for _, tr := range trs {
+		if tr.OffsetAfter > tr.OffsetBefore {
+			starts = append(starts, tr)
+		} else {
+			ends = append(ends, tr)
+		}
+	}
+	if len(starts) != posixRuleYears || len(ends) != posixRuleYears {
+		return "", fmt.Errorf("no stable rule for %s: transitions do not alternate", loc)
+	}
+	var std, dst = ends[0], starts[0]
+	for i := range starts {
+		if starts[i].OffsetBefore != std.OffsetAfter || starts[i].OffsetAfter != dst.OffsetAfter ||
+			starts[i].AbbreviationBefore != std.AbbreviationAfter || starts[i].AbbreviationAfter != dst.AbbreviationAfter ||
+			ends[i].OffsetBefore != dst.OffsetAfter || ends[i].OffsetAfter != std.OffsetAfter ||
+			ends[i].AbbreviationBefore != dst.AbbreviationAfter || ends[i].AbbreviationAfter != std.AbbreviationAfter {
+			return "", fmt.Errorf("no stable rule for %s: offsets change", loc)
+		}
+	}
+
+	start, err := posixRule(starts)
+	if err != nil {
+		return "", fmt.Errorf("no stable rule for %s: %w", loc, err)
+	}
+	end, err := posixRule(ends)
+	if err != nil {
+		return "", fmt.Errorf("no stable rule for %s: %w", loc, err)
+	}
+
+	var b strings.Builder
+	b.WriteString(posixName(std.AbbreviationAfter))
+	b.WriteString(posixOffset(std.OffsetAfter))
+	b.WriteString(posixName(dst.AbbreviationAfter))
+	if dst.OffsetAfter != std.OffsetAfter+3600 {
+		b.WriteString(posixOffset(dst.OffsetAfter))
+	}
+	b.WriteString("," + start + "," + end)
+	return b.String(), nil
+}
+
+// posixRule returns the Mm.w.d[/time] rule that yields every transition, which are a year apart.
+func posixRule(trs []Transition) (string, error) {
+	for _, shift := range posixDayShifts {
+		var (
+			month   time.Month
+			weekday time.Weekday
+			seconds int
+			weeks   = 1<<6 - 1
+		)
+		for i, tr := range trs {
+			// Rules are given in the wall clock time in force before the transition.
+			var wall = tr.At.Add(time.Duration(tr.OffsetBefore) * time.Second).UTC()
+			var date = time.Date(wall.Year(), wall.Month(), wall.Day()-shift, 0, 0, 0, 0, time.UTC)
+			var secs = wall.Hour()*3600 + wall.Minute()*60 + wall.Second() + shift*24*3600
+			if i == 0 {
+				month, weekday, seconds = date.Month(), date.Weekday(), secs
+			} else if date.Month() != month || date.Weekday() != weekday || secs != seconds {
+				weeks = 0
+				break
+			}
+
+			// The week is the nth occurrence of the weekday in the month, or 5 for the last one.
+			var mask = 1 << uint((date.Day()-1)/7+1)
+			if date.Day()+7 > daysIn(date.Year(), date.Month()) {
+				mask |= 1 << 5
+			}
+			weeks &= mask
+		}
+		for w := 5; w >= 1 && weeks != 0; w-- {
+			if weeks&(1<<uint(w)) == 0 {
+				continue
+			}
+			var rule = fmt.Sprintf("M%d.%d.%d", month, w, weekday)
+			if seconds != posixDefaultTime {
+				rule += "/" + posixTime(seconds)
+			}
+			return rule, nil
+		}
+	}
+	return "", fmt.Errorf("transitions on %v do not follow a weekday rule", trs[0].At)
+}
+
+// posixName returns an abbreviation, quoting it unless it is alphabetic and at least three letters long.
+func posixName(name string) string {
+	if len(name) < 3 || strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z')
+	}) >= 0 {
+		return "<" + name + ">"
+	}
+	return name
+}
+
+// posixOffset formats an offset in seconds east of UTC, which POSIX writes with the opposite sign.
+func posixOffset(offset int) string {
+	return posixTime(-offset)
+}
+
+// posixTime formats seconds as [-]h[:mm[:ss]].
+func posixTime(seconds int) string {
+	var sign string
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	var s = sign + strconv.Itoa(seconds/3600)
+	if seconds%3600 != 0 {
+		s += fmt.Sprintf(":%02d", seconds/60%60)
+		if seconds%60 != 0 {
+			s += fmt.Sprintf(":%02d", seconds%60)
+		}
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package tz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPosixTZ(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var at = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if s, err := fc.PosixTZ(40.5, -109.5, at); err != nil || s != "MST7MDT,M3.2.0,M11.1.0" {
+		t.Errorf("denver: got %q %v", s, err)
+	}
+	if s, err := fc.PosixTZ(-17, 178, at); err != nil || s != "<+12>-12" {
+		t.Errorf("fiji: got %q %v", s, err)
+	}
+	if _, err := fc.PosixTZ(40.5, -107, at); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
+
+func TestPosixTZRules(t *testing.T) {
+	var at = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		TZID string
+		TZ   string
+	}{
+		{TZID: "America/Denver", TZ: "MST7MDT,M3.2.0,M11.1.0"},
+		{TZID: "Europe/Berlin", TZ: "CET-1CEST,M3.5.0,M10.5.0/3"},
+		{TZID: "Europe/London", TZ: "GMT0BST,M3.5.0/1,M10.5.0"},
+		{TZID: "Australia/Sydney", TZ: "AEST-10AEDT,M10.1.0,M4.1.0/3"},
+		{TZID: "Australia/Lord_Howe", TZ: "<+1030>-10:30<+11>-11,M10.1.0,M4.1.0"},
+		{TZID: "Pacific/Chatham", TZ: "<+1245>-12:45<+1345>,M9.5.0/2:45,M4.1.0/3:45"},
+		{TZID: "America/St_Johns", TZ: "NST3:30NDT,M3.2.0,M11.1.0"},
+		{TZID: "America/Santiago", TZ: "<-04>4<-03>,M9.1.6/24,M4.1.6/24"},
+		{TZID: "America/Nuuk", TZ: "<-02>2<-01>,M3.5.0/-1,M10.5.0/0"},
+		{TZID: "Asia/Jerusalem", TZ: "IST-2IDT,M3.4.4/26,M10.5.0"},
+		{TZID: "Asia/Kolkata", TZ: "IST-5:30"},
+		{TZID: "UTC", TZ: "UTC0"},
+	}
+	for _, test := range tests {
+		loc, err := LoadLocation(test.TZID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, err := posixTZ(loc, at); err != nil || s != test.TZ {
+			t.Errorf("%s: got %q %v, expected %q", test.TZID, s, err, test.TZ)
+		}
+	}
+
+	// Morocco suspends daylight saving time for Ramadan, which no POSIX rule describes.
+	loc, err := LoadLocation("Africa/Casablanca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := posixTZ(loc, at); err == nil {
+		t.Errorf("casablanca: got %q, expected error", s)
+	}
+}
+
+func TestPosixTime(t *testing.T) {
+	var tests = map[int]string{0: "0", 7200: "2", -3600: "-1", 26 * 3600: "26", 9900: "2:45", 5*3600 + 30*60 + 15: "5:30:15"}
+	for seconds, want := range tests {
+		if s := posixTime(seconds); s != want {
+			t.Errorf("%d: got %q, expected %q", seconds, s, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package tz
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// transitionStep is the interval at which zone offsets are sampled when searching for transitions.
+	// Zones have never changed offset twice within it.
+	transitionStep = 12 * time.Hour
+	// transitionHorizon bounds how far from an instant LocalTime searches for transitions.
+	transitionHorizon = 10 * 366 * 24 * time.Hour
+)
+
+// Transition is a change of UTC offset or abbreviation in a time zone.
+type Transition struct {
+	// At is the first instant with the new offset, in UTC.
+	At                 time.Time
+	OffsetBefore       int
+	OffsetAfter        int
+	AbbreviationBefore string
+	AbbreviationAfter  string
+}
+
+// Transitions returns every change of UTC offset or abbreviation in the time zone at a coordinate
+// from the instant from up to but excluding to.
+func (fc Collection) Transitions(lat, lon float64, from, to time.Time) ([]Transition, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("end %v before start %v", to, from)
+	}
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := LoadLocation(tzid)
+	if err != nil {
+		return nil, err
+	}
+	return transitions(loc, from, to), nil
+}
+
+// transitions returns the transitions in loc in [from, to).
+func transitions(loc *time.Location, from, to time.Time) []Transition {
+	var trs []Transition
+	for t := from.Add(-time.Second); ; {
+		tr, ok := nextTransition(loc, t, to)
+		if !ok || !tr.At.Before(to) {
+			return trs
+		}
+		if !tr.At.Before(from) {
+			trs = append(trs, tr)
+		}
+		t = tr.At
+	}
+}
+
+// yearTransitions caches the transitions of time zones by UTC calendar year, keyed by zoneYear.
+var yearTransitions sync.Map
+
+type zoneYear struct {
+	tzid string
+	year int
+}
+
+// transitionsInYear returns the transitions in loc during a UTC calendar year, searched for only once.
+func transitionsInYear(loc *time.Location, year int) []Transition {
+	var key = zoneYear{tzid: loc.String(), year: year}
+	if trs, ok := yearTransitions.Load(key); ok {
+		return trs.([]Transition)
+	}
+	trs := transitions(loc, time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	yearTransitions.Store(key, trs)
+	return trs
+}
+
+// surroundingTransitions returns the last transition in loc at or before t and the first one after it,
+// each within transitionHorizon of t, from the cached transitions of each year.
+func surroundingTransitions(loc *time.Location, t time.Time) (previous, next *Transition) {
+	var (
+		year     = t.UTC().Year()
+		earliest = t.Add(-transitionHorizon)
+		latest   = t.Add(transitionHorizon)
+	)
+	for y := year; y >= earliest.UTC().Year() && previous == nil; y-- {
+		trs := transitionsInYear(loc, y)
+		i := sort.Search(len(trs), func(i int) bool { return trs[i].At.After(t) })
+		if i > 0 && trs[i-1].At.After(earliest) {
+			tr := trs[i-1]
+			previous = &tr
+		}
+	}
+	for y := year; y <= latest.UTC().Year() && next == nil; y++ {
+		trs := transitionsInYear(loc, y)
+		i := sort.Search(len(trs), func(i int) bool { return trs[i].At.After(t) })
+		if i < len(trs) && !trs[i].At.After(latest) {
+			tr := trs[i]
+			next = &tr
+		}
+	}
+	return previous, next
+}
+
+// nextTransition returns the first transition in loc after t and no later than limit.
+func nextTransition(loc *time.Location, t, limit time.Time) (Transition, bool) {
+	var name, offset = t.In(loc).Zone()
+	for lo := t; lo.Before(limit); lo = lo.Add(transitionStep) {
+		hi := lo.Add(transitionStep)
+		if hi.After(limit) {
+			hi = limit
+		}
+		if n, o := hi.In(loc).Zone(); n != name || o != offset {
+			return findTransition(loc, lo, hi), true
+		}
+	}
+	return Transition{}, false
+}
+
+// findTransition bisects to the second between lo and hi, which differ in offset or abbreviation.
+func findTransition(loc *time.Location, lo, hi time.Time) Transition {
+	var (
+		loName, loOffset = lo.In(loc).Zone()
+		l, h             = lo.Unix(), hi.Unix()
+	)
+	for h-l > 1 {
+		m := l + (h-l)/2
+		if n, o := time.Unix(m, 0).In(loc).Zone(); n == loName && o == loOffset {
+			l = m
+		} else {
+			h = m
+		}
+	}
+
+	var at = time.Unix(h, 0).UTC()
+	var before, offsetBefore = time.Unix(l, 0).In(loc).Zone()
+	var after, offsetAfter = at.In(loc).Zone()
+	return Transition{
+		At:                 at,
+		OffsetBefore:       offsetBefore,
+		OffsetAfter:        offsetAfter,
+		AbbreviationBefore: before,
+		AbbreviationAfter:  after,
+	}
+}
@@ -0,0 +1,55 @@
+package tz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransitions(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var (
+		from = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to   = time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	trs, err := fc.Transitions(40.5, -109.5, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trs) != 10 {
+		t.Fatalf("got %d transitions, expected 10", len(trs))
+	}
+	var want = []Transition{
+		{At: time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC), OffsetBefore: -7 * 3600, OffsetAfter: -6 * 3600,
+			AbbreviationBefore: "MST", AbbreviationAfter: "MDT"},
+		{At: time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC), OffsetBefore: -6 * 3600, OffsetAfter: -7 * 3600,
+			AbbreviationBefore: "MDT", AbbreviationAfter: "MST"},
+	}
+	for i := range want {
+		if trs[i] != want[i] {
+			t.Errorf("transition %d: got %+v, expected %+v", i, trs[i], want[i])
+		}
+	}
+	if last := trs[9].At; !last.Equal(time.Date(2030, 11, 3, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("last transition at %v", last)
+	}
+
+	// The range includes its start and excludes its end.
+	trs, _ = fc.Transitions(40.5, -109.5, want[0].At, want[1].At)
+	if len(trs) != 1 || trs[0] != want[0] {
+		t.Errorf("got %+v, expected only %+v", trs, want[0])
+	}
+	trs, _ = fc.Transitions(40.5, -109.5, want[0].At.Add(time.Millisecond), want[1].At.Add(time.Millisecond))
+	if len(trs) != 1 || trs[0] != want[1] {
+		t.Errorf("got %+v, expected only %+v", trs, want[1])
+	}
+
+	if trs, err = fc.Transitions(-17, 178, from, to); err != nil || len(trs) != 0 {
+		t.Errorf("fiji: got %+v %v, expected none", trs, err)
+	}
+	if _, err = fc.Transitions(40.5, -109.5, to, from); err == nil {
+		t.Error("expected error for reversed range")
+	}
+	if _, err = fc.Transitions(40.5, -107, from, to); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
@@ -0,0 +1,89 @@
+package tzgrpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/catmullet/tz"
+	"github.com/catmullet/tz/tzgrpc/tzpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// DefaultTimeout bounds each call made through the tz.TimeZoneLookup methods of a Client.
+const DefaultTimeout = 5 * time.Second
+
+// Client looks up time zones from a remote TimeZoneService. It implements tz.TimeZoneLookup.
+type Client struct {
+	client  tzpb.TimeZoneServiceClient
+	timeout time.Duration
+}
+
+var _ tz.TimeZoneLookup = (*Client)(nil)
+
+// NewClient returns a Client calling the service over conn.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{client: tzpb.NewTimeZoneServiceClient(conn), timeout: DefaultTimeout}
+}
+
+// WithTimeout returns a copy of the client whose tz.TimeZoneLookup calls time out after d.
+func (c *Client) WithTimeout(d time.Duration) *Client {
+	return &Client{client: c.client, timeout: d}
+}
+
+// TimeZone returns the time zone at the coordinate, or an empty string when none was found or the call failed.
+func (c *Client) TimeZone(lat, lon float64) string {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	tzid, _ := c.TimeZoneContext(ctx, lat, lon)
+	return tzid
+}
+
+// Location returns the location of the time zone at the coordinate.
+func (c *Client) Location(lat, lon float64) (*time.Location, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	tzid, err := c.TimeZoneContext(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	if tzid == "" {
+		return nil, fmt.Errorf("failed to find time zone")
+	}
+	return tz.LoadLocation(tzid)
+}
+
+// TimeZoneContext returns the time zone at the coordinate. A coordinate outside every time zone
+// returns an empty string and no error.
+func (c *Client) TimeZoneContext(ctx context.Context, lat, lon float64) (string, error) {
+	resp, err := c.client.Lookup(ctx, &tzpb.LookupRequest{Lat: lat, Lon: lon})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.Tzid, nil
+}
+
+// TimeZones resolves many coordinates in one call, given as lat, lon pairs. The result has an
+// empty string where no time zone was found.
+func (c *Client) TimeZones(ctx context.Context, coords [][2]float64) ([]string, error) {
+	var req = &tzpb.BatchLookupRequest{Coordinates: make([]*tzpb.LookupRequest, len(coords))}
+	for i, coord := range coords {
+		req.Coordinates[i] = &tzpb.LookupRequest{Lat: coord[0], Lon: coord[1]}
+	}
+	resp, err := c.client.BatchLookup(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(coords) {
+		return nil, fmt.Errorf("batch lookup returned %d results for %d coordinates", len(resp.Results), len(coords))
+	}
+	var tzids = make([]string, len(resp.Results))
+	for i, r := range resp.Results {
+		tzids[i] = r.Tzid
+	}
+	return tzids, nil
+}
@@ -0,0 +1,11 @@
+module github.com/catmullet/tz/tzgrpc
+
+go 1.16
+
+require (
+	github.com/catmullet/tz v0.0.0
+	google.golang.org/grpc v1.46.2
+	google.golang.org/protobuf v1.28.0
+)
+
+replace github.com/catmullet/tz => ../
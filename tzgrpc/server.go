@@ -0,0 +1,94 @@
+// Package tzgrpc serves a tz.TimeZoneLookup over gRPC and provides a client that is itself a
+// tz.TimeZoneLookup, so local and remote lookups can be swapped without changing callers.
+package tzgrpc
+
+import (
+	"context"
+	"github.com/catmullet/tz"
+	"github.com/catmullet/tz/tzgrpc/tzpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"time"
+)
+
+// Server implements tzpb.TimeZoneServiceServer on top of a lookup.
+type Server struct {
+	tzpb.UnimplementedTimeZoneServiceServer
+
+	lookup tz.TimeZoneLookup
+	now    func() time.Time
+}
+
+// NewServer returns a Server answering from lookup. Register it with tzpb.RegisterTimeZoneServiceServer.
+func NewServer(lookup tz.TimeZoneLookup) *Server {
+	return &Server{lookup: lookup, now: time.Now}
+}
+
+// Lookup resolves a single coordinate, failing with codes.NotFound when no time zone contains it.
+func (s *Server) Lookup(_ context.Context, req *tzpb.LookupRequest) (*tzpb.LookupResponse, error) {
+	resp, err := s.resolve(req, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if resp.Tzid == "" {
+		return nil, status.Errorf(codes.NotFound, "no time zone found at %v, %v", req.Lat, req.Lon)
+	}
+	return resp, nil
+}
+
+// BatchLookup resolves every coordinate in the request, leaving tzid empty where none was found.
+func (s *Server) BatchLookup(ctx context.Context, req *tzpb.BatchLookupRequest) (*tzpb.BatchLookupResponse, error) {
+	var (
+		now  = s.now()
+		resp = &tzpb.BatchLookupResponse{Results: make([]*tzpb.LookupResponse, len(req.Coordinates))}
+	)
+	for i, c := range req.Coordinates {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		result, err := s.resolve(c, now)
+		if err != nil {
+			return nil, err
+		}
+		resp.Results[i] = result
+	}
+	return resp, nil
+}
+
+// StreamLookup answers each coordinate received on the stream in order, leaving tzid empty where none was found.
+func (s *Server) StreamLookup(stream tzpb.TimeZoneService_StreamLookupServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp, err := s.resolve(req, s.now())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) resolve(req *tzpb.LookupRequest, now time.Time) (*tzpb.LookupResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing coordinate")
+	}
+	resp := &tzpb.LookupResponse{Lat: req.Lat, Lon: req.Lon, Tzid: s.lookup.TimeZone(req.Lat, req.Lon)}
+	if resp.Tzid == "" {
+		return resp, nil
+	}
+	loc, err := tz.LoadLocation(resp.Tzid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load location %q: %v", resp.Tzid, err)
+	}
+	abbreviation, offset := now.In(loc).Zone()
+	resp.Abbreviation, resp.OffsetSeconds = abbreviation, int32(offset)
+	return resp, nil
+}
@@ -0,0 +1,158 @@
+package tzgrpc
+
+import (
+	"context"
+	"github.com/catmullet/tz/tzgrpc/tzpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+type fakeLookup map[[2]float64]string
+
+func (f fakeLookup) TimeZone(lat, lon float64) string {
+	return f[[2]float64{lat, lon}]
+}
+
+func (f fakeLookup) Location(lat, lon float64) (*time.Location, error) {
+	return time.LoadLocation(f.TimeZone(lat, lon))
+}
+
+var lookup = fakeLookup{
+	{41.8976, -87.6205}: "America/Chicago",
+	{-33.9249, 18.4241}: "Africa/Johannesburg",
+}
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	var (
+		lis = bufconn.Listen(1 << 20)
+		srv = grpc.NewServer()
+		s   = NewServer(lookup)
+	)
+	s.now = func() time.Time {
+		return time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	}
+	tzpb.RegisterTimeZoneServiceServer(srv, s)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+func TestLookup(t *testing.T) {
+	var client = tzpb.NewTimeZoneServiceClient(newTestConn(t))
+
+	resp, err := client.Lookup(context.Background(), &tzpb.LookupRequest{Lat: 41.8976, Lon: -87.6205})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Tzid != "America/Chicago" || resp.OffsetSeconds != -6*3600 || resp.Abbreviation != "CST" {
+		t.Errorf("got %v", resp)
+	}
+
+	_, err = client.Lookup(context.Background(), &tzpb.LookupRequest{Lat: 0, Lon: 0})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("got %v, expected NotFound", err)
+	}
+}
+
+func TestBatchLookup(t *testing.T) {
+	var client = tzpb.NewTimeZoneServiceClient(newTestConn(t))
+
+	resp, err := client.BatchLookup(context.Background(), &tzpb.BatchLookupRequest{Coordinates: []*tzpb.LookupRequest{
+		{Lat: 41.8976, Lon: -87.6205}, {Lat: 0, Lon: 0}, {Lat: -33.9249, Lon: 18.4241},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want = []string{"America/Chicago", "", "Africa/Johannesburg"}
+	if len(resp.Results) != len(want) {
+		t.Fatalf("got %v", resp.Results)
+	}
+	for i, r := range resp.Results {
+		if r.Tzid != want[i] {
+			t.Errorf("%d: got %q, expected %q", i, r.Tzid, want[i])
+		}
+	}
+	if resp.Results[2].OffsetSeconds != 2*3600 || resp.Results[2].Abbreviation != "SAST" {
+		t.Errorf("got %v", resp.Results[2])
+	}
+}
+
+func TestStreamLookup(t *testing.T) {
+	var client = tzpb.NewTimeZoneServiceClient(newTestConn(t))
+
+	stream, err := client.StreamLookup(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var coords = []*tzpb.LookupRequest{{Lat: -33.9249, Lon: 18.4241}, {Lat: 1, Lon: 1}, {Lat: 41.8976, Lon: -87.6205}}
+	var want = []string{"Africa/Johannesburg", "", "America/Chicago"}
+	for i, c := range coords {
+		if err := stream.Send(c); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Tzid != want[i] || resp.Lat != c.Lat || resp.Lon != c.Lon {
+			t.Errorf("%d: got %v, expected %q", i, resp, want[i])
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("got %v, expected EOF", err)
+	}
+}
+
+func TestClient(t *testing.T) {
+	var client = NewClient(newTestConn(t))
+
+	for coord, tzid := range lookup {
+		if got := client.TimeZone(coord[0], coord[1]); got != tzid {
+			t.Errorf("%v: got %q, expected %q", coord, got, tzid)
+		}
+		loc, err := client.Location(coord[0], coord[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loc.String() != tzid {
+			t.Errorf("%v: got location %v", coord, loc)
+		}
+	}
+	if got := client.TimeZone(0, 0); got != "" {
+		t.Errorf("got %q outside every zone", got)
+	}
+	if _, err := client.Location(0, 0); err == nil {
+		t.Error("expected error outside every zone")
+	}
+
+	tzids, err := client.TimeZones(context.Background(), [][2]float64{{41.8976, -87.6205}, {0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tzids) != 2 || tzids[0] != "America/Chicago" || tzids[1] != "" {
+		t.Errorf("got %q", tzids)
+	}
+}
@@ -0,0 +1,4 @@
+// Package tzpb holds the protocol buffer and gRPC definitions of the time zone lookup service.
+package tzpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative timezone.proto
@@ -0,0 +1,218 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: timezone.proto
+
+package tzpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// TimeZoneServiceClient is the client API for TimeZoneService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TimeZoneServiceClient interface {
+	// Lookup resolves a single coordinate. It fails with NOT_FOUND when no time zone contains it.
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	// BatchLookup resolves many coordinates at once. Results are in request order and have an
+	// empty tzid where no time zone was found.
+	BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (*BatchLookupResponse, error)
+	// StreamLookup answers each request on the stream as it arrives, in order.
+	StreamLookup(ctx context.Context, opts ...grpc.CallOption) (TimeZoneService_StreamLookupClient, error)
+}
+
+type timeZoneServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTimeZoneServiceClient(cc grpc.ClientConnInterface) TimeZoneServiceClient {
+	return &timeZoneServiceClient{cc}
+}
+
+func (c *timeZoneServiceClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, "/tz.v1.TimeZoneService/Lookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeZoneServiceClient) BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (*BatchLookupResponse, error) {
+	out := new(BatchLookupResponse)
+	err := c.cc.Invoke(ctx, "/tz.v1.TimeZoneService/BatchLookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timeZoneServiceClient) StreamLookup(ctx context.Context, opts ...grpc.CallOption) (TimeZoneService_StreamLookupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TimeZoneService_ServiceDesc.Streams[0], "/tz.v1.TimeZoneService/StreamLookup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &timeZoneServiceStreamLookupClient{stream}
+	return x, nil
+}
+
+type TimeZoneService_StreamLookupClient interface {
+	Send(*LookupRequest) error
+	Recv() (*LookupResponse, error)
+	grpc.ClientStream
+}
+
+type timeZoneServiceStreamLookupClient struct {
+	grpc.ClientStream
+}
+
+func (x *timeZoneServiceStreamLookupClient) Send(m *LookupRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *timeZoneServiceStreamLookupClient) Recv() (*LookupResponse, error) {
+	m := new(LookupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TimeZoneServiceServer is the server API for TimeZoneService service.
+// All implementations must embed UnimplementedTimeZoneServiceServer
+// for forward compatibility
+type TimeZoneServiceServer interface {
+	// Lookup resolves a single coordinate. It fails with NOT_FOUND when no time zone contains it.
+	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
+	// BatchLookup resolves many coordinates at once. Results are in request order and have an
+	// empty tzid where no time zone was found.
+	BatchLookup(context.Context, *BatchLookupRequest) (*BatchLookupResponse, error)
+	// StreamLookup answers each request on the stream as it arrives, in order.
+	StreamLookup(TimeZoneService_StreamLookupServer) error
+	mustEmbedUnimplementedTimeZoneServiceServer()
+}
+
+// UnimplementedTimeZoneServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTimeZoneServiceServer struct {
+}
+
+func (UnimplementedTimeZoneServiceServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedTimeZoneServiceServer) BatchLookup(context.Context, *BatchLookupRequest) (*BatchLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchLookup not implemented")
+}
+func (UnimplementedTimeZoneServiceServer) StreamLookup(TimeZoneService_StreamLookupServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLookup not implemented")
+}
+func (UnimplementedTimeZoneServiceServer) mustEmbedUnimplementedTimeZoneServiceServer() {}
+
+// UnsafeTimeZoneServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TimeZoneServiceServer will
+// result in compilation errors.
+type UnsafeTimeZoneServiceServer interface {
+	mustEmbedUnimplementedTimeZoneServiceServer()
+}
+
+func RegisterTimeZoneServiceServer(s grpc.ServiceRegistrar, srv TimeZoneServiceServer) {
+	s.RegisterService(&TimeZoneService_ServiceDesc, srv)
+}
+
+func _TimeZoneService_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeZoneServiceServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tz.v1.TimeZoneService/Lookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeZoneServiceServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeZoneService_BatchLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeZoneServiceServer).BatchLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tz.v1.TimeZoneService/BatchLookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeZoneServiceServer).BatchLookup(ctx, req.(*BatchLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimeZoneService_StreamLookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TimeZoneServiceServer).StreamLookup(&timeZoneServiceStreamLookupServer{stream})
+}
+
+type TimeZoneService_StreamLookupServer interface {
+	Send(*LookupResponse) error
+	Recv() (*LookupRequest, error)
+	grpc.ServerStream
+}
+
+type timeZoneServiceStreamLookupServer struct {
+	grpc.ServerStream
+}
+
+func (x *timeZoneServiceStreamLookupServer) Send(m *LookupResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *timeZoneServiceStreamLookupServer) Recv() (*LookupRequest, error) {
+	m := new(LookupRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TimeZoneService_ServiceDesc is the grpc.ServiceDesc for TimeZoneService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TimeZoneService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tz.v1.TimeZoneService",
+	HandlerType: (*TimeZoneServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _TimeZoneService_Lookup_Handler,
+		},
+		{
+			MethodName: "BatchLookup",
+			Handler:    _TimeZoneService_BatchLookup_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLookup",
+			Handler:       _TimeZoneService_StreamLookup_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "timezone.proto",
+}
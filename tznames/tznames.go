@@ -0,0 +1,265 @@
+// Package tznames gives localized display names of time zones, such as "Mitteleuropäische Sommerzeit" or
+// "MST", from the Unicode CLDR data embedded in the package.
+//
+// The data is regenerated with cmd/zonenames.
+package tznames
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"github.com/catmullet/tz/internal/zoneinfo"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Style selects the form of a time zone name.
+type Style int
+
+const (
+	// LongGeneric names the zone without regard to daylight saving time, "Central European Time".
+	LongGeneric Style = iota
+	// LongSpecific names the standard or daylight time in effect, "Central European Summer Time".
+	LongSpecific
+	// ShortGeneric abbreviates the generic name, "MT".
+	ShortGeneric
+	// ShortSpecific abbreviates the specific name, "MDT".
+	ShortSpecific
+)
+
+// periodLayout is the layout of the UTC times bounding metazone periods.
+const periodLayout = "2006-01-02 15:04"
+
+//go:embed data/*.json
+var data embed.FS
+
+var (
+	metazonesOnce sync.Once
+	metazones     map[string][]period
+	metazonesErr  error
+	locales       sync.Map
+)
+
+// period is a span of time a zone used a metazone, an empty bound is open.
+type period struct {
+	Metazone string `json:"metazone"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+}
+
+type locale struct {
+	HourFormat    string           `json:"hourFormat"`
+	GMTFormat     string           `json:"gmtFormat"`
+	GMTZeroFormat string           `json:"gmtZeroFormat"`
+	RegionFormat  string           `json:"regionFormat"`
+	Metazones     map[string]names `json:"metazones"`
+	Zones         map[string]names `json:"zones"`
+}
+
+type names struct {
+	LongGeneric   string `json:"lg,omitempty"`
+	LongStandard  string `json:"ls,omitempty"`
+	LongDaylight  string `json:"ld,omitempty"`
+	ShortGeneric  string `json:"sg,omitempty"`
+	ShortStandard string `json:"ss,omitempty"`
+	ShortDaylight string `json:"sd,omitempty"`
+	ExemplarCity  string `json:"ec,omitempty"`
+}
+
+// Languages returns the languages names are available in, sorted.
+func Languages() []string {
+	entries, _ := fs.ReadDir(data, "data")
+	var langs []string
+	for _, e := range entries {
+		if lang := strings.TrimSuffix(e.Name(), ".json"); lang != "metazones" {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Name returns the name of a time zone in a language at time t. The language is a BCP 47 tag matched on its
+// base language, so "de-CH" gets the German names. A name missing from the data falls back as CLDR
+// specifies, to the city the zone is named after for generic names and to a GMT offset such as "GMT+01:00"
+// for specific ones.
+func Name(tzid, lang string, t time.Time, style Style) (string, error) {
+	tzid = zoneinfo.Canonical(tzid)
+	loc, err := zoneinfo.LoadLocation(tzid)
+	if err != nil || tzid == "" {
+		return "", fmt.Errorf("failed to load time zone %q", tzid)
+	}
+	l, err := loadLocale(lang)
+	if err != nil {
+		return "", err
+	}
+	periods, err := loadMetazones()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		zone         = l.Zones[tzid]
+		meta         = l.Metazones[metazoneAt(periods[tzid], t)]
+		_, offset    = t.In(loc).Zone()
+		std, usesDST = zoneinfo.StandardOffset(loc, t.In(loc).Year())
+		dst          = offset > std
+	)
+	switch style {
+	case LongGeneric:
+		if name := first(zone.LongGeneric, meta.LongGeneric); name != "" {
+			return name, nil
+		}
+		if name := first(zone.LongStandard, meta.LongStandard); name != "" && !usesDST {
+			return name, nil
+		}
+		return strings.Replace(l.RegionFormat, "{0}", exemplarCity(tzid, zone), 1), nil
+	case LongSpecific:
+		var name = first(zone.LongStandard, meta.LongStandard)
+		if dst {
+			name = first(zone.LongDaylight, meta.LongDaylight)
+		}
+		if name != "" {
+			return name, nil
+		}
+		return l.gmt(offset, false), nil
+	case ShortGeneric:
+		if name := first(zone.ShortGeneric, meta.ShortGeneric); name != "" {
+			return name, nil
+		}
+		if name := first(zone.ShortStandard, meta.ShortStandard); name != "" && !usesDST {
+			return name, nil
+		}
+		return l.gmt(offset, true), nil
+	case ShortSpecific:
+		var name = first(zone.ShortStandard, meta.ShortStandard)
+		if dst {
+			name = first(zone.ShortDaylight, meta.ShortDaylight)
+		}
+		if name != "" {
+			return name, nil
+		}
+		return l.gmt(offset, true), nil
+	}
+	return "", fmt.Errorf("unknown style %d", style)
+}
+
+// Lookup finds the time zone at a coordinate. It is the part of tz.TimeZoneLookup NameAt uses, declared here
+// so that the package does not link the time zone polygons.
+type Lookup interface {
+	TimeZone(lat, lon float64) string
+}
+
+// NameAt returns the name of the time zone at a coordinate in a language at time t, see Name.
+func NameAt(lookup Lookup, lat, lon float64, lang string, t time.Time, style Style) (string, error) {
+	tzid := lookup.TimeZone(lat, lon)
+	if tzid == "" {
+		return "", fmt.Errorf("failed to find time zone at %v, %v", lat, lon)
+	}
+	return Name(tzid, lang, t, style)
+}
+
+func loadLocale(lang string) (*locale, error) {
+	var base = strings.ToLower(lang)
+	if i := strings.IndexAny(base, "-_"); i >= 0 {
+		base = base[:i]
+	}
+	if l, ok := locales.Load(base); ok {
+		return l.(*locale), nil
+	}
+	b, err := fs.ReadFile(data, "data/"+base+".json")
+	if err != nil || base == "" || base == "metazones" {
+		return nil, fmt.Errorf("unsupported language %q", lang)
+	}
+	var l locale
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse names for %q, %w", base, err)
+	}
+	actual, _ := locales.LoadOrStore(base, &l)
+	return actual.(*locale), nil
+}
+
+func loadMetazones() (map[string][]period, error) {
+	metazonesOnce.Do(func() {
+		b, err := fs.ReadFile(data, "data/metazones.json")
+		if err != nil {
+			metazonesErr = err
+			return
+		}
+		if err := json.Unmarshal(b, &metazones); err != nil {
+			metazonesErr = fmt.Errorf("failed to parse metazones, %w", err)
+		}
+	})
+	return metazones, metazonesErr
+}
+
+// metazoneAt returns the metazone of the period containing t.
+func metazoneAt(periods []period, t time.Time) string {
+	var at = t.UTC().Format(periodLayout)
+	for _, p := range periods {
+		if (p.From == "" || p.From <= at) && (p.To == "" || at < p.To) {
+			return p.Metazone
+		}
+	}
+	return ""
+}
+
+// exemplarCity returns the city a zone is named after, the last element of the tzid unless the locale
+// translates it.
+func exemplarCity(tzid string, zone names) string {
+	if zone.ExemplarCity != "" {
+		return zone.ExemplarCity
+	}
+	return strings.ReplaceAll(tzid[strings.LastIndex(tzid, "/")+1:], "_", " ")
+}
+
+// gmt formats an offset in seconds east of UTC as the localized GMT format, "GMT+01:00" or, short, "GMT+1".
+func (l *locale) gmt(offset int, short bool) string {
+	if offset == 0 && l.GMTZeroFormat != "" {
+		return l.GMTZeroFormat
+	}
+	var pattern = l.HourFormat
+	if i := strings.Index(pattern, ";"); i >= 0 {
+		if offset < 0 {
+			pattern = pattern[i+1:]
+		} else {
+			pattern = pattern[:i]
+		}
+	}
+	if offset < 0 {
+		offset = -offset
+	}
+	var hours, minutes = offset / 3600, offset % 3600 / 60
+	if short {
+		pattern = strings.Replace(pattern, "HH", "H", 1)
+		// Whole hours drop the minutes along with the separator in front of them.
+		if i := strings.Index(pattern, "mm"); i > 0 && minutes == 0 {
+			start := i
+			if pattern[i-1] != 'H' {
+				start = i - 1
+			}
+			pattern = pattern[:start] + pattern[i+2:]
+		}
+	}
+	if strings.Contains(pattern, "HH") {
+		pattern = strings.Replace(pattern, "HH", fmt.Sprintf("%02d", hours), 1)
+	} else {
+		pattern = strings.Replace(pattern, "H", strconv.Itoa(hours), 1)
+	}
+	pattern = strings.Replace(pattern, "mm", fmt.Sprintf("%02d", minutes), 1)
+	return strings.Replace(l.GMTFormat, "{0}", pattern, 1)
+}
+
+// first returns the first of its arguments that is not empty.
+func first(names ...string) string {
+	for _, name := range names {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
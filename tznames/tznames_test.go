@@ -0,0 +1,155 @@
+package tznames
+
+import (
+	"github.com/catmullet/tz"
+	"go/build"
+	"testing"
+	"time"
+)
+
+func TestName(t *testing.T) {
+	var (
+		summer = time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+		winter = time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+	)
+	var tests = []struct {
+		TZID  string
+		Lang  string
+		Time  time.Time
+		Style Style
+		Name  string
+	}{
+		{TZID: "Europe/Berlin", Lang: "de", Time: summer, Style: LongSpecific, Name: "Mitteleuropäische Sommerzeit"},
+		{TZID: "Europe/Berlin", Lang: "de-CH", Time: winter, Style: LongSpecific, Name: "Mitteleuropäische Normalzeit"},
+		{TZID: "Europe/Berlin", Lang: "en", Time: winter, Style: LongGeneric, Name: "Central European Time"},
+		{TZID: "America/Denver", Lang: "en", Time: winter, Style: LongSpecific, Name: "Mountain Standard Time"},
+		{TZID: "America/Denver", Lang: "en-US", Time: winter, Style: ShortSpecific, Name: "MST"},
+		{TZID: "America/Denver", Lang: "en", Time: summer, Style: ShortSpecific, Name: "MDT"},
+		{TZID: "America/Denver", Lang: "en", Time: summer, Style: ShortGeneric, Name: "MT"},
+		{TZID: "Europe/London", Lang: "de", Time: summer, Style: LongSpecific, Name: "Britische Sommerzeit"},
+		{TZID: "Europe/London", Lang: "en", Time: winter, Style: LongSpecific, Name: "Greenwich Mean Time"},
+		{TZID: "Asia/Calcutta", Lang: "en", Time: winter, Style: LongGeneric, Name: "India Standard Time"},
+		{TZID: "Asia/Kolkata", Lang: "en", Time: winter, Style: ShortSpecific, Name: "GMT+5:30"},
+		{TZID: "Asia/Kolkata", Lang: "en", Time: winter, Style: ShortGeneric, Name: "GMT+5:30"},
+		{TZID: "Europe/Berlin", Lang: "en", Time: summer, Style: ShortSpecific, Name: "GMT+2"},
+		{TZID: "America/Indiana/Knox", Lang: "en", Time: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), Style: LongSpecific, Name: "Eastern Standard Time"},
+		{TZID: "America/Indiana/Knox", Lang: "en", Time: winter, Style: LongSpecific, Name: "Central Standard Time"},
+		{TZID: "Etc/GMT-5", Lang: "en", Time: winter, Style: LongSpecific, Name: "GMT+05:00"},
+		{TZID: "Etc/GMT+3", Lang: "en", Time: winter, Style: ShortSpecific, Name: "GMT-3"},
+	}
+	for _, test := range tests {
+		name, err := Name(test.TZID, test.Lang, test.Time, test.Style)
+		if err != nil {
+			t.Errorf("%s %s: %v", test.TZID, test.Lang, err)
+			continue
+		}
+		if name != test.Name {
+			t.Errorf("%s %s %d: got %q, expected %q", test.TZID, test.Lang, test.Style, name, test.Name)
+		}
+	}
+}
+
+func TestNameErrors(t *testing.T) {
+	var now = time.Now()
+	if _, err := Name("Europe/Berlin", "tlh", now, LongGeneric); err == nil {
+		t.Error("unsupported language: expected error")
+	}
+	if _, err := Name("Europe/Berlin", "", now, LongGeneric); err == nil {
+		t.Error("empty language: expected error")
+	}
+	if _, err := Name("Mars/Olympus_Mons", "en", now, LongGeneric); err == nil {
+		t.Error("unknown zone: expected error")
+	}
+	if _, err := Name("Europe/Berlin", "en", now, Style(9)); err == nil {
+		t.Error("unknown style: expected error")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	langs := Languages()
+	if len(langs) == 0 || langs[0] != "ar" {
+		t.Fatalf("got %v, expected languages starting with ar", langs)
+	}
+	for _, lang := range langs {
+		if lang == "metazones" {
+			t.Errorf("got %v, expected only languages", langs)
+		}
+		if _, err := Name("Europe/Paris", lang, time.Now(), LongSpecific); err != nil {
+			t.Errorf("%s: %v", lang, err)
+		}
+	}
+}
+
+func TestGMT(t *testing.T) {
+	var l = &locale{HourFormat: "+HH:mm;-HH:mm", GMTFormat: "GMT{0}", GMTZeroFormat: "GMT"}
+	var tests = []struct {
+		Offset int
+		Short  bool
+		GMT    string
+	}{
+		{Offset: 0, GMT: "GMT"},
+		{Offset: 3600, GMT: "GMT+01:00"},
+		{Offset: 3600, Short: true, GMT: "GMT+1"},
+		{Offset: -9*3600 - 1800, GMT: "GMT-09:30"},
+		{Offset: -9*3600 - 1800, Short: true, GMT: "GMT-9:30"},
+		{Offset: 14 * 3600, Short: true, GMT: "GMT+14"},
+	}
+	for _, test := range tests {
+		if got := l.gmt(test.Offset, test.Short); got != test.GMT {
+			t.Errorf("%d %v: got %q, expected %q", test.Offset, test.Short, got, test.GMT)
+		}
+	}
+}
+
+type lookupFunc func(lat, lon float64) string
+
+func (f lookupFunc) TimeZone(lat, lon float64) string { return f(lat, lon) }
+
+func (f lookupFunc) Location(lat, lon float64) (*time.Location, error) {
+	return time.LoadLocation(f(lat, lon))
+}
+
+func TestNameAt(t *testing.T) {
+	var lookup tz.TimeZoneLookup = lookupFunc(func(lat, lon float64) string {
+		if lat > 0 {
+			return "Europe/Paris"
+		}
+		return ""
+	})
+	name, err := NameAt(lookup, 48.8566, 2.3522, "fr", time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), LongSpecific)
+	if err != nil || name != "heure d’été d’Europe centrale" {
+		t.Errorf("got %q, %v, expected heure d’été d’Europe centrale", name, err)
+	}
+	if _, err := NameAt(lookup, -10, 0, "fr", time.Now(), LongSpecific); err == nil {
+		t.Error("no zone: expected error")
+	}
+}
+
+func TestNoPolygons(t *testing.T) {
+	// The package must not import the time zone polygons, which are larger than all its own data.
+	const polygons = "github.com/catmullet/tz/geodb"
+	var (
+		seen  = make(map[string]bool)
+		visit func(path, dir string)
+	)
+	visit = func(path, dir string) {
+		if seen[path] || t.Failed() {
+			return
+		}
+		seen[path] = true
+		pkg, err := build.Import(path, dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pkg.Goroot {
+			return
+		}
+		for _, imp := range pkg.Imports {
+			if imp == polygons {
+				t.Errorf("%s imports %s", path, polygons)
+			}
+			visit(imp, pkg.Dir)
+		}
+	}
+	visit("github.com/catmullet/tz/tznames", ".")
+}
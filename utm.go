@@ -0,0 +1,119 @@
+package tz
+
+import (
+	"fmt"
+	"math"
+)
+
+// WGS84 ellipsoid and UTM projection constants.
+const (
+	wgs84A          = 6378137.0
+	wgs84F          = 1 / 298.257223563
+	utmScale        = 0.9996
+	utmFalseEasting = 500000.0
+	// utmFalseNorthing is added to northings in the southern hemisphere.
+	utmFalseNorthing = 10000000.0
+)
+
+// Coefficients of Krüger's series for the transverse Mercator projection to third order in n. A round trip
+// through toUTM and UTM.LatLon lands within a millimeter of the start.
+var (
+	utmN     = wgs84F / (2 - wgs84F)
+	utmA     = wgs84A / (1 + utmN) * (1 + utmN*utmN/4 + utmN*utmN*utmN*utmN/64)
+	utmAlpha = [3]float64{
+		utmN/2 - 2*utmN*utmN/3 + 5*utmN*utmN*utmN/16,
+		13*utmN*utmN/48 - 3*utmN*utmN*utmN/5,
+		61 * utmN * utmN * utmN / 240,
+	}
+	utmBeta = [3]float64{
+		utmN/2 - 2*utmN*utmN/3 + 37*utmN*utmN*utmN/96,
+		utmN*utmN/48 + utmN*utmN*utmN/15,
+		17 * utmN * utmN * utmN / 480,
+	}
+	utmDelta = [3]float64{
+		2*utmN - 2*utmN*utmN/3 - 2*utmN*utmN*utmN,
+		7*utmN*utmN/3 - 8*utmN*utmN*utmN/5,
+		56 * utmN * utmN * utmN / 15,
+	}
+)
+
+// UTM is a Universal Transverse Mercator coordinate on the WGS84 ellipsoid.
+type UTM struct {
+	Zone     int
+	North    bool
+	Easting  float64
+	Northing float64
+}
+
+// LatLon converts the coordinate to WGS84 latitude and longitude.
+func (u UTM) LatLon() (lat, lon float64, err error) {
+	if u.Zone < 1 || u.Zone > 60 {
+		return 0, 0, fmt.Errorf("invalid UTM zone %d", u.Zone)
+	}
+	if u.Easting <= 0 || u.Easting >= 1000000 || u.Northing < 0 || u.Northing > utmFalseNorthing {
+		return 0, 0, fmt.Errorf("UTM coordinate %v %v out of range", u.Easting, u.Northing)
+	}
+
+	var northing = u.Northing
+	if !u.North {
+		northing -= utmFalseNorthing
+	}
+	var (
+		xi0  = northing / (utmScale * utmA)
+		eta0 = (u.Easting - utmFalseEasting) / (utmScale * utmA)
+		xi   = xi0
+		eta  = eta0
+	)
+	// Every term of the series is evaluated at the original ξ and η, not at the partial sums.
+	for j, b := range utmBeta {
+		k := 2 * float64(j+1)
+		xi -= b * math.Sin(k*xi0) * math.Cosh(k*eta0)
+		eta -= b * math.Cos(k*xi0) * math.Sinh(k*eta0)
+	}
+
+	var chi = math.Asin(math.Sin(xi) / math.Cosh(eta))
+	var phi = chi
+	for j, d := range utmDelta {
+		phi += d * math.Sin(2*float64(j+1)*chi)
+	}
+	lat = phi * 180 / math.Pi
+	lon = utmCentralMeridian(u.Zone) + math.Atan2(math.Sinh(eta), math.Cos(xi))*180/math.Pi
+	return lat, normalizeLon(lon), nil
+}
+
+// toUTM projects a latitude and longitude into the given UTM zone.
+func toUTM(lat, lon float64, zone int) UTM {
+	var (
+		phi    = lat * math.Pi / 180
+		lambda = (lon - utmCentralMeridian(zone)) * math.Pi / 180
+		c      = 2 * math.Sqrt(utmN) / (1 + utmN)
+		t      = math.Sinh(math.Atanh(math.Sin(phi)) - c*math.Atanh(c*math.Sin(phi)))
+		xi     = math.Atan2(t, math.Cos(lambda))
+		eta    = math.Atanh(math.Sin(lambda) / math.Sqrt(1+t*t))
+		x, y   = eta, xi
+	)
+	for j, a := range utmAlpha {
+		k := 2 * float64(j+1)
+		x += a * math.Cos(k*xi) * math.Sinh(k*eta)
+		y += a * math.Sin(k*xi) * math.Cosh(k*eta)
+	}
+
+	var u = UTM{Zone: zone, North: lat >= 0, Easting: utmFalseEasting + utmScale*utmA*x, Northing: utmScale * utmA * y}
+	if !u.North {
+		u.Northing += utmFalseNorthing
+	}
+	return u
+}
+
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone)*6 - 183
+}
+
+// TimeZoneUTM returns the time zone at a UTM coordinate.
+func (fc Collection) TimeZoneUTM(u UTM) (string, error) {
+	lat, lon, err := u.LatLon()
+	if err != nil {
+		return "", err
+	}
+	return fc.timeZone(lat, lon)
+}
@@ -0,0 +1,120 @@
+package tz
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUTMLatLon(t *testing.T) {
+	var tests = []struct {
+		Name string
+		UTM  UTM
+		Lat  float64
+		Lon  float64
+	}{
+		{Name: "baghdad", UTM: UTM{Zone: 38, North: true, Easting: 444140.54, Northing: 3684706.36}, Lat: 33.3, Lon: 44.4},
+		{Name: "equator on central meridian", UTM: UTM{Zone: 31, North: true, Easting: 500000, Northing: 0}, Lat: 0, Lon: 3},
+		{Name: "equator southern false northing", UTM: UTM{Zone: 31, North: false, Easting: 500000, Northing: 10000000}, Lat: 0, Lon: 3},
+		{Name: "zone 1 wraps", UTM: UTM{Zone: 1, North: true, Easting: 500000, Northing: 0}, Lat: 0, Lon: -177},
+		{Name: "zone 60", UTM: UTM{Zone: 60, North: true, Easting: 500000, Northing: 0}, Lat: 0, Lon: 177},
+	}
+	for _, test := range tests {
+		lat, lon, err := test.UTM.LatLon()
+		if err != nil {
+			t.Errorf("%s: %v", test.Name, err)
+			continue
+		}
+		if math.Abs(lat-test.Lat) > 1e-7 || math.Abs(lon-test.Lon) > 1e-7 {
+			t.Errorf("%s: got %v %v, expected %v %v", test.Name, lat, lon, test.Lat, test.Lon)
+		}
+	}
+
+	for _, u := range []UTM{{Zone: 0, Easting: 500000}, {Zone: 61, Easting: 500000}, {Zone: 31, Easting: -1},
+		{Zone: 31, Easting: 500000, Northing: -1}, {Zone: 31, Easting: 500000, Northing: 10000001}} {
+		if _, _, err := u.LatLon(); err == nil {
+			t.Errorf("%+v: expected error", u)
+		}
+	}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	// Projecting into a zone and back must land within a millimeter of the start, from the central
+	// meridian out past the zone edges and over the full latitude range of UTM.
+	const tolerance = 0.001
+	for _, zone := range []int{1, 17, 31, 44, 60} {
+		for lat := -80.0; lat <= 84; lat += 2.5 {
+			for offset := -4.0; offset <= 4; offset += 0.5 {
+				var lon = normalizeLon(utmCentralMeridian(zone) + offset)
+				lat2, lon2, err := toUTM(lat, lon, zone).LatLon()
+				if err != nil {
+					t.Errorf("zone %d %v %v: %v", zone, lat, lon, err)
+					continue
+				}
+				var (
+					north = (lat2 - lat) * math.Pi / 180 * wgs84A
+					east  = normalizeLon(lon2-lon) * math.Pi / 180 * wgs84A * math.Cos(lat*math.Pi/180)
+				)
+				if d := math.Hypot(north, east); d > tolerance {
+					t.Errorf("zone %d %v %v: got %v %v, %.3g m away", zone, lat, lon, lat2, lon2, d)
+				}
+			}
+		}
+	}
+}
+
+func TestParseMGRS(t *testing.T) {
+	var tests = []struct {
+		Ref       string
+		UTM       UTM
+		Precision float64
+	}{
+		{Ref: "38SMB4414084706", UTM: UTM{Zone: 38, North: true, Easting: 444140, Northing: 3684706}, Precision: 1},
+		{Ref: "38S MB 441 847", UTM: UTM{Zone: 38, North: true, Easting: 444100, Northing: 3684700}, Precision: 100},
+		{Ref: "4QFJ1234567890", UTM: UTM{Zone: 4, North: true, Easting: 612345, Northing: 2367890}, Precision: 1},
+		{Ref: "4qfj", UTM: UTM{Zone: 4, North: true, Easting: 600000, Northing: 2300000}, Precision: 100000},
+		{Ref: "32VNM0000000000", UTM: UTM{Zone: 32, North: true, Easting: 500000, Northing: 6600000}, Precision: 1},
+		{Ref: "56HLH3487352266", UTM: UTM{Zone: 56, North: false, Easting: 334873, Northing: 6252266}, Precision: 1},
+		{Ref: "38MNB1000000000", UTM: UTM{Zone: 38, North: false, Easting: 510000, Northing: 9600000}, Precision: 1},
+	}
+	for _, test := range tests {
+		u, precision, err := ParseMGRS(test.Ref)
+		if err != nil {
+			t.Errorf("%s: %v", test.Ref, err)
+			continue
+		}
+		if u != test.UTM || precision != test.Precision {
+			t.Errorf("%s: got %+v %v, expected %+v %v", test.Ref, u, precision, test.UTM, test.Precision)
+		}
+	}
+
+	lat, lon, _ := UTM{Zone: 4, North: true, Easting: 612345, Northing: 2367890}.LatLon()
+	if math.Abs(lat-21.409797) > 1e-5 || math.Abs(lon+157.916081) > 1e-5 {
+		t.Errorf("4QFJ1234567890: got %v %v", lat, lon)
+	}
+
+	for _, ref := range []string{"", "4Q", "61QFJ", "0QFJ", "4AFJ", "4QIJ", "4QFW", "4QFJ123", "4QFJ12345678901", "4QFJ12x4"} {
+		if _, _, err := ParseMGRS(ref); err == nil {
+			t.Errorf("%q: expected error", ref)
+		}
+	}
+}
+
+func TestTimeZoneProjected(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	// 109.5W 40.5N, inside the western arm of America/Denver.
+	var u = toUTM(40.5, -109.5, 12)
+
+	if tz, err := fc.TimeZoneUTM(u); err != nil || tz != "America/Denver" {
+		t.Errorf("utm: got %q %v", tz, err)
+	}
+	if tz, err := fc.TimeZoneMGRS("12TXK"); err != nil || tz != "America/Denver" {
+		t.Errorf("mgrs: got %q %v", tz, err)
+	}
+	x, y := -109.5*math.Pi/180*wgs84A, wgs84A*math.Log(math.Tan(math.Pi/4+40.5*math.Pi/360))
+	if tz, err := fc.TimeZoneWebMercator(x, y); err != nil || tz != "America/Denver" {
+		t.Errorf("web mercator: got %q %v", tz, err)
+	}
+	if _, err := fc.TimeZoneUTM(toUTM(40.5, -107, 13)); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
@@ -0,0 +1,85 @@
+package tz
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// icalDateTime is the RFC 5545 DATE-TIME format for local times.
+const icalDateTime = "20060102T150405"
+
+// VTimezone returns an RFC 5545 VTIMEZONE component for the time zone at a coordinate covering the years
+// from fromYear to toYear inclusive. Every transition in the range is written as its own STANDARD or DAYLIGHT
+// sub-component, preceded by one for the offset in force at the start of fromYear.
+func (fc Collection) VTimezone(lat, lon float64, fromYear, toYear int) (string, error) {
+	if toYear < fromYear {
+		return "", fmt.Errorf("end year %d before start year %d", toYear, fromYear)
+	}
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return "", err
+	}
+	loc, err := LoadLocation(tzid)
+	if err != nil {
+		return "", err
+	}
+	return vtimezone(tzid, loc, fromYear, toYear), nil
+}
+
+func vtimezone(tzid string, loc *time.Location, fromYear, toYear int) string {
+	var (
+		b    strings.Builder
+		from = time.Date(fromYear, time.January, 1, 0, 0, 0, 0, loc)
+		to   = time.Date(toYear+1, time.January, 1, 0, 0, 0, 0, loc)
+	)
+	writeICalLine(&b, "BEGIN:VTIMEZONE")
+	writeICalLine(&b, "TZID:"+tzid)
+
+	name, offset := from.Zone()
+	writeObservance(&b, loc, from.Year(), from.Format(icalDateTime), offset, offset, name)
+	for _, tr := range transitions(loc, from, to) {
+		var onset = tr.At.Add(time.Duration(tr.OffsetBefore) * time.Second).UTC()
+		writeObservance(&b, loc, tr.At.In(loc).Year(), onset.Format(icalDateTime), tr.OffsetBefore, tr.OffsetAfter,
+			tr.AbbreviationAfter)
+	}
+
+	writeICalLine(&b, "END:VTIMEZONE")
+	return b.String()
+}
+
+// writeObservance writes a STANDARD or DAYLIGHT sub-component. Its onset is the local time in the offset before it.
+func writeObservance(b *strings.Builder, loc *time.Location, year int, onset string, offsetFrom, offsetTo int, name string) {
+	var kind = "STANDARD"
+	if std, _ := StandardOffset(loc, year); offsetTo > std {
+		kind = "DAYLIGHT"
+	}
+	writeICalLine(b, "BEGIN:"+kind)
+	writeICalLine(b, "DTSTART:"+onset)
+	writeICalLine(b, "TZOFFSETFROM:"+icalOffset(offsetFrom))
+	writeICalLine(b, "TZOFFSETTO:"+icalOffset(offsetTo))
+	writeICalLine(b, "TZNAME:"+name)
+	writeICalLine(b, "END:"+kind)
+}
+
+// writeICalLine writes a content line ending in CRLF, folded into lines of at most 75 octets as RFC 5545 requires.
+func writeICalLine(b *strings.Builder, line string) {
+	for limit := 75; len(line) > limit; limit = 74 {
+		b.WriteString(line[:limit] + "\r\n ")
+		line = line[limit:]
+	}
+	b.WriteString(line + "\r\n")
+}
+
+// icalOffset formats an offset in seconds east of UTC as +hhmm, or +hhmmss when it has seconds.
+func icalOffset(offset int) string {
+	var sign = "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	var s = fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
@@ -0,0 +1,196 @@
+package tz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type observance struct {
+	Kind       string
+	Onset      time.Time
+	OffsetFrom int
+	OffsetTo   int
+	Name       string
+}
+
+// parseVTimezone parses a single VTIMEZONE component, checking its structure along the way.
+func parseVTimezone(s string) (string, []observance, error) {
+	if !strings.HasSuffix(s, "\r\n") {
+		return "", nil, fmt.Errorf("missing final CRLF")
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			return "", nil, fmt.Errorf("line longer than 75 octets: %q", line)
+		}
+		if strings.HasPrefix(line, " ") && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) < 2 || lines[0] != "BEGIN:VTIMEZONE" || lines[len(lines)-1] != "END:VTIMEZONE" {
+		return "", nil, fmt.Errorf("not a VTIMEZONE")
+	}
+
+	var (
+		tzid        string
+		observances []observance
+		current     *observance
+	)
+	for _, line := range lines[1 : len(lines)-1] {
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			return "", nil, fmt.Errorf("invalid line %q", line)
+		}
+		name, value := line[:i], line[i+1:]
+		switch {
+		case name == "TZID" && current == nil:
+			tzid = value
+		case name == "BEGIN" && current == nil && (value == "STANDARD" || value == "DAYLIGHT"):
+			current = &observance{Kind: value}
+		case name == "END" && current != nil && value == current.Kind:
+			observances = append(observances, *current)
+			current = nil
+		case current == nil:
+			return "", nil, fmt.Errorf("unexpected line %q", line)
+		case name == "DTSTART":
+			t, err := time.Parse(icalDateTime, value)
+			if err != nil {
+				return "", nil, err
+			}
+			current.Onset = t
+		case name == "TZOFFSETFROM" || name == "TZOFFSETTO":
+			offset, err := parseICalOffset(value)
+			if err != nil {
+				return "", nil, err
+			}
+			if name == "TZOFFSETFROM" {
+				current.OffsetFrom = offset
+			} else {
+				current.OffsetTo = offset
+			}
+		case name == "TZNAME":
+			current.Name = value
+		default:
+			return "", nil, fmt.Errorf("unexpected property %q", line)
+		}
+	}
+	if tzid == "" || current != nil || len(observances) == 0 {
+		return "", nil, fmt.Errorf("incomplete VTIMEZONE")
+	}
+	return tzid, observances, nil
+}
+
+func parseICalOffset(s string) (int, error) {
+	if len(s) != 5 && len(s) != 7 || s[0] != '+' && s[0] != '-' {
+		return 0, fmt.Errorf("invalid offset %q", s)
+	}
+	var offset int
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(s) {
+			break
+		}
+		v, err := strconv.Atoi(s[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, err
+		}
+		offset += v * unit
+	}
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// observanceAt returns the observance in force at t, the one with the latest onset at or before it.
+func observanceAt(observances []observance, t time.Time) (observance, bool) {
+	var found, ok = observance{}, false
+	for _, o := range observances {
+		onset := o.Onset.Add(-time.Duration(o.OffsetFrom) * time.Second)
+		if !onset.After(t) && (!ok || onset.After(found.Onset.Add(-time.Duration(found.OffsetFrom)*time.Second))) {
+			found, ok = o, true
+		}
+	}
+	return found, ok
+}
+
+func TestVTimezone(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	s, err := fc.VTimezone(40.5, -109.5, 2026, 2027)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tzid, observances, err := parseVTimezone(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want = []observance{
+		{Kind: "STANDARD", Onset: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), OffsetFrom: -7 * 3600, OffsetTo: -7 * 3600, Name: "MST"},
+		{Kind: "DAYLIGHT", Onset: time.Date(2026, 3, 8, 2, 0, 0, 0, time.UTC), OffsetFrom: -7 * 3600, OffsetTo: -6 * 3600, Name: "MDT"},
+		{Kind: "STANDARD", Onset: time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC), OffsetFrom: -6 * 3600, OffsetTo: -7 * 3600, Name: "MST"},
+		{Kind: "DAYLIGHT", Onset: time.Date(2027, 3, 14, 2, 0, 0, 0, time.UTC), OffsetFrom: -7 * 3600, OffsetTo: -6 * 3600, Name: "MDT"},
+		{Kind: "STANDARD", Onset: time.Date(2027, 11, 7, 2, 0, 0, 0, time.UTC), OffsetFrom: -6 * 3600, OffsetTo: -7 * 3600, Name: "MST"},
+	}
+	if tzid != "America/Denver" || len(observances) != len(want) {
+		t.Fatalf("got %s %+v", tzid, observances)
+	}
+	for i := range want {
+		if observances[i] != want[i] {
+			t.Errorf("observance %d: got %+v, expected %+v", i, observances[i], want[i])
+		}
+	}
+
+	if _, err := fc.VTimezone(40.5, -109.5, 2027, 2026); err == nil {
+		t.Error("expected error for reversed years")
+	}
+	if _, err := fc.VTimezone(40.5, -107, 2026, 2027); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
+
+func TestVTimezoneRoundTrip(t *testing.T) {
+	for _, tzid := range []string{"America/Denver", "Australia/Sydney", "America/Nuuk", "Asia/Kolkata", "Africa/Casablanca",
+		"Pacific/Chatham", "Europe/Dublin", "Pacific/Fiji"} {
+		loc, err := LoadLocation(tzid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, observances, err := parseVTimezone(vtimezone(tzid, loc, 2020, 2030))
+		if err != nil || parsed != tzid {
+			t.Errorf("%s: got %q %v", tzid, parsed, err)
+			continue
+		}
+		var end = time.Date(2031, 1, 1, 0, 0, 0, 0, loc)
+		for at := time.Date(2020, 1, 1, 0, 0, 0, 0, loc); at.Before(end); at = at.Add(5 * time.Hour) {
+			name, offset := at.In(loc).Zone()
+			o, ok := observanceAt(observances, at)
+			if !ok || o.OffsetTo != offset || o.Name != name {
+				t.Errorf("%s at %v: got %+v, expected %s %d", tzid, at, o, name, offset)
+				break
+			}
+		}
+	}
+}
+
+func TestICalLineFolding(t *testing.T) {
+	var b strings.Builder
+	writeICalLine(&b, "TZID:"+strings.Repeat("x", 200))
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets", len(line))
+		}
+	}
+	if unfolded := strings.ReplaceAll(b.String(), "\r\n ", ""); unfolded != "TZID:"+strings.Repeat("x", 200)+"\r\n" {
+		t.Errorf("got %q", unfolded)
+	}
+	if s := icalOffset(-(3*3600 + 30*60)); s != "-0330" {
+		t.Errorf("got offset %q", s)
+	}
+	if s := icalOffset(5*3600 + 53*60 + 28); s != "+055328" {
+		t.Errorf("got offset %q", s)
+	}
+}
@@ -0,0 +1,115 @@
+package tz
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrAmbiguousTime is returned by LocalToUTC with ResolveError for a wall clock time that occurs twice,
+	// when clocks are set back.
+	ErrAmbiguousTime = errors.New("ambiguous local time")
+	// ErrNonexistentTime is returned by LocalToUTC with ResolveError for a wall clock time that is skipped,
+	// when clocks are set forward.
+	ErrNonexistentTime = errors.New("nonexistent local time")
+)
+
+// Resolution selects the instant LocalToUTC returns for ambiguous and nonexistent wall clock times.
+// Earlier and later refer to the two instants found by reading the wall clock with the offsets before
+// and after the transition, so for a skipped time earlier falls before the transition and later after it.
+type Resolution int
+
+const (
+	ResolveEarlier Resolution = iota
+	ResolveLater
+	ResolveError
+)
+
+// WallClock is the result of converting a wall clock time at a coordinate to UTC.
+type WallClock struct {
+	TZID string
+	// Time is the chosen instant in the zone, zero when the policy is ResolveError and the time is not unique.
+	Time time.Time
+	// Instants are the UTC instants showing the wall clock time, two when Ambiguous and none when Nonexistent.
+	Instants    []time.Time
+	Ambiguous   bool
+	Nonexistent bool
+}
+
+// LocalToUTC converts a wall clock time at a coordinate to an instant, reporting ambiguous and nonexistent
+// times explicitly and resolving them by policy. Fields out of range are an error rather than normalized.
+func (fc Collection) LocalToUTC(lat, lon float64, year int, month time.Month, day, hour, min, sec, nsec int,
+	policy Resolution) (WallClock, error) {
+	if month < time.January || month > time.December || day < 1 || day > daysIn(year, month) ||
+		hour < 0 || hour > 23 || min < 0 || min > 59 || sec < 0 || sec > 59 || nsec < 0 || nsec > 999999999 {
+		return WallClock{}, fmt.Errorf("invalid local time %04d-%02d-%02d %02d:%02d:%02d.%09d",
+			year, month, day, hour, min, sec, nsec)
+	}
+
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return WallClock{}, err
+	}
+	loc, err := LoadLocation(tzid)
+	if err != nil {
+		return WallClock{}, err
+	}
+
+	var (
+		wc         = WallClock{TZID: tzid}
+		wall       = time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+		candidates []time.Time
+	)
+	// Read the wall clock with every offset in use around it and keep the instants that show it.
+	for _, around := range []time.Time{wall.Add(-24 * time.Hour), wall, wall.Add(24 * time.Hour)} {
+		_, offset := around.In(loc).Zone()
+		at := wall.Add(-time.Duration(offset) * time.Second)
+		if containsTime(candidates, at) {
+			continue
+		}
+		candidates = append(candidates, at)
+		if _, o := at.In(loc).Zone(); o == offset {
+			wc.Instants = append(wc.Instants, at)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	sort.Slice(wc.Instants, func(i, j int) bool { return wc.Instants[i].Before(wc.Instants[j]) })
+
+	switch {
+	case len(wc.Instants) == 1:
+		wc.Time = wc.Instants[0].In(loc)
+		return wc, nil
+	case len(wc.Instants) > 1:
+		wc.Ambiguous, candidates = true, wc.Instants
+	default:
+		wc.Nonexistent = true
+	}
+
+	switch policy {
+	case ResolveEarlier:
+		wc.Time = candidates[0].In(loc)
+	case ResolveLater:
+		wc.Time = candidates[len(candidates)-1].In(loc)
+	default:
+		if wc.Ambiguous {
+			return wc, ErrAmbiguousTime
+		}
+		return wc, ErrNonexistentTime
+	}
+	return wc, nil
+}
+
+func containsTime(times []time.Time, t time.Time) bool {
+	for _, v := range times {
+		if v.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,80 @@
+package tz
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLocalToUTC(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var tests = []struct {
+		Name        string
+		Time        [5]int
+		Policy      Resolution
+		UTC         string
+		Instants    int
+		Ambiguous   bool
+		Nonexistent bool
+		Err         error
+	}{
+		{Name: "unique", Time: [5]int{2026, 7, 4, 12, 0}, Policy: ResolveError, UTC: "2026-07-04T18:00:00Z", Instants: 1},
+		{Name: "fall back earlier", Time: [5]int{2026, 11, 1, 1, 30}, Policy: ResolveEarlier, UTC: "2026-11-01T07:30:00Z",
+			Instants: 2, Ambiguous: true},
+		{Name: "fall back later", Time: [5]int{2026, 11, 1, 1, 30}, Policy: ResolveLater, UTC: "2026-11-01T08:30:00Z",
+			Instants: 2, Ambiguous: true},
+		{Name: "fall back error", Time: [5]int{2026, 11, 1, 1, 30}, Policy: ResolveError, Instants: 2, Ambiguous: true,
+			Err: ErrAmbiguousTime},
+		{Name: "after fall back", Time: [5]int{2026, 11, 1, 2, 0}, Policy: ResolveError, UTC: "2026-11-01T09:00:00Z", Instants: 1},
+		{Name: "spring forward earlier", Time: [5]int{2026, 3, 8, 2, 30}, Policy: ResolveEarlier, UTC: "2026-03-08T08:30:00Z",
+			Nonexistent: true},
+		{Name: "spring forward later", Time: [5]int{2026, 3, 8, 2, 30}, Policy: ResolveLater, UTC: "2026-03-08T09:30:00Z",
+			Nonexistent: true},
+		{Name: "spring forward error", Time: [5]int{2026, 3, 8, 2, 30}, Policy: ResolveError, Nonexistent: true,
+			Err: ErrNonexistentTime},
+		{Name: "end of the gap", Time: [5]int{2026, 3, 8, 3, 0}, Policy: ResolveError, UTC: "2026-03-08T09:00:00Z", Instants: 1},
+	}
+	for _, test := range tests {
+		wc, err := fc.LocalToUTC(40.5, -109.5, test.Time[0], time.Month(test.Time[1]), test.Time[2], test.Time[3], test.Time[4],
+			0, 0, test.Policy)
+		if !errors.Is(err, test.Err) {
+			t.Errorf("%s: got error %v, expected %v", test.Name, err, test.Err)
+			continue
+		}
+		var utc string
+		if !wc.Time.IsZero() {
+			utc = wc.Time.UTC().Format(time.RFC3339)
+		}
+		if wc.TZID != "America/Denver" || utc != test.UTC || len(wc.Instants) != test.Instants ||
+			wc.Ambiguous != test.Ambiguous || wc.Nonexistent != test.Nonexistent {
+			t.Errorf("%s: got %+v, expected %s with %d instants", test.Name, wc, test.UTC, test.Instants)
+		}
+		if wc.Time.Location().String() != "America/Denver" && !wc.Time.IsZero() {
+			t.Errorf("%s: got location %s", test.Name, wc.Time.Location())
+		}
+	}
+}
+
+func TestLocalToUTCInvalid(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var tests = [][7]int{
+		{2026, 2, 29, 12, 0, 0, 0},
+		{2026, 13, 1, 12, 0, 0, 0},
+		{2026, 1, 0, 12, 0, 0, 0},
+		{2026, 1, 1, 24, 0, 0, 0},
+		{2026, 1, 1, 12, 60, 0, 0},
+		{2026, 1, 1, 12, 0, 60, 0},
+		{2026, 1, 1, 12, 0, 0, 1000000000},
+	}
+	for _, f := range tests {
+		if _, err := fc.LocalToUTC(40.5, -109.5, f[0], time.Month(f[1]), f[2], f[3], f[4], f[5], f[6], ResolveEarlier); err == nil {
+			t.Errorf("%v: expected error", f)
+		}
+	}
+	if _, err := fc.LocalToUTC(40.5, -109.5, 2028, 2, 29, 12, 0, 0, 0, ResolveError); err != nil {
+		t.Errorf("leap day: %v", err)
+	}
+	if _, err := fc.LocalToUTC(40.5, -107, 2026, 1, 1, 12, 0, 0, 0, ResolveError); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
@@ -0,0 +1,117 @@
+package tz
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/catmullet/tz/internal/zoneinfo"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+const (
+	windowsZonesFilename = "windowsZones.txt"
+	// windowsDefaultTerritory is the CLDR territory of the zone used when no territory matches.
+	windowsDefaultTerritory = "001"
+)
+
+// windowsZones is the CLDR mapping between Windows time zone IDs and IANA time zones.
+type windowsZones struct {
+	// byTZID maps each IANA time zone to its Windows ID.
+	byTZID map[string]string
+	// byWindows maps each Windows ID and territory to the territory's canonical IANA time zone.
+	byWindows map[string]map[string]string
+}
+
+var (
+	windowsOnce    sync.Once
+	windowsMapping windowsZones
+	windowsErr     error
+)
+
+func loadWindowsZones() (windowsZones, error) {
+	windowsOnce.Do(func() {
+		var b []byte
+		if b, windowsErr = fs.ReadFile(zoneinfo.FS, windowsZonesFilename); windowsErr == nil {
+			windowsMapping, windowsErr = parseWindowsZones(b)
+		}
+	})
+	return windowsMapping, windowsErr
+}
+
+// parseWindowsZones parses the lines written by cmd/windowszones: a Windows ID, a territory and the
+// territory's IANA time zones separated by tabs.
+func parseWindowsZones(b []byte) (windowsZones, error) {
+	var (
+		wz      = windowsZones{byTZID: make(map[string]string), byWindows: make(map[string]map[string]string)}
+		scanner = bufio.NewScanner(bytes.NewReader(b))
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) != 3 || len(strings.Fields(fields[2])) == 0 {
+			return windowsZones{}, fmt.Errorf("failed to parse %s line %d", windowsZonesFilename, line)
+		}
+		windowsID, territory, tzids := fields[0], fields[1], strings.Fields(fields[2])
+
+		if wz.byWindows[windowsID] == nil {
+			wz.byWindows[windowsID] = make(map[string]string)
+		}
+		wz.byWindows[windowsID][territory] = tzids[0]
+		for _, tzid := range tzids {
+			// A zone listed as a default wins over other Windows IDs listing it.
+			if _, ok := wz.byTZID[tzid]; !ok || territory == windowsDefaultTerritory {
+				wz.byTZID[tzid] = windowsID
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return windowsZones{}, err
+	}
+	return wz, nil
+}
+
+// WindowsZone returns the Windows time zone ID, such as "Central Standard Time", for an IANA time zone or one of its aliases.
+func WindowsZone(tzid string) (string, error) {
+	wz, err := loadWindowsZones()
+	if err != nil {
+		return "", fmt.Errorf("failed to load windows zones, %w", err)
+	}
+	if windowsID, ok := wz.byTZID[tzid]; ok {
+		return windowsID, nil
+	}
+	if windowsID, ok := wz.byTZID[CanonicalTimeZone(tzid)]; ok {
+		return windowsID, nil
+	}
+	return "", fmt.Errorf("no windows time zone for %q", tzid)
+}
+
+// WindowsToIANA returns the canonical IANA time zone for a Windows time zone ID in a territory, given as an
+// ISO 3166 alpha-2 code. Territories without their own entry, and an empty territory, get the Windows zone's default.
+func WindowsToIANA(windowsID, territory string) (string, error) {
+	wz, err := loadWindowsZones()
+	if err != nil {
+		return "", fmt.Errorf("failed to load windows zones, %w", err)
+	}
+	territories, ok := wz.byWindows[windowsID]
+	if !ok {
+		return "", fmt.Errorf("unknown windows time zone %q", windowsID)
+	}
+	if tzid, ok := territories[strings.ToUpper(territory)]; ok {
+		return tzid, nil
+	}
+	return territories[windowsDefaultTerritory], nil
+}
+
+// WindowsTimeZone returns the Windows time zone ID for the time zone at a coordinate.
+func (fc Collection) WindowsTimeZone(lat, lon float64) (string, error) {
+	tzid, err := fc.timeZone(lat, lon)
+	if err != nil {
+		return "", err
+	}
+	return WindowsZone(tzid)
+}
@@ -0,0 +1,72 @@
+package tz
+
+import "testing"
+
+func TestWindowsZone(t *testing.T) {
+	var tests = map[string]string{
+		"America/Chicago":                "Central Standard Time",
+		"America/Denver":                 "Mountain Standard Time",
+		"America/Winnipeg":               "Central Standard Time",
+		"Europe/Berlin":                  "W. Europe Standard Time",
+		"Asia/Kolkata":                   "India Standard Time",
+		"America/Argentina/Buenos_Aires": "Argentina Standard Time",
+		"Europe/Kyiv":                    "FLE Standard Time",
+		"Pacific/Fiji":                   "Fiji Standard Time",
+		"Etc/UTC":                        "UTC",
+	}
+	for tzid, want := range tests {
+		if windowsID, err := WindowsZone(tzid); err != nil || windowsID != want {
+			t.Errorf("%s: got %q %v, expected %q", tzid, windowsID, err, want)
+		}
+	}
+	if _, err := WindowsZone("Mars/Olympus_Mons"); err == nil {
+		t.Error("expected error for unknown time zone")
+	}
+}
+
+func TestWindowsToIANA(t *testing.T) {
+	var tests = []struct {
+		WindowsID string
+		Territory string
+		TZID      string
+	}{
+		{WindowsID: "Central Standard Time", Territory: "", TZID: "America/Chicago"},
+		{WindowsID: "Central Standard Time", Territory: "US", TZID: "America/Chicago"},
+		{WindowsID: "Central Standard Time", Territory: "ca", TZID: "America/Winnipeg"},
+		{WindowsID: "Central Standard Time", Territory: "MX", TZID: "America/Matamoros"},
+		{WindowsID: "Central Standard Time", Territory: "FR", TZID: "America/Chicago"},
+		{WindowsID: "W. Europe Standard Time", Territory: "CH", TZID: "Europe/Zurich"},
+		{WindowsID: "India Standard Time", Territory: "IN", TZID: "Asia/Kolkata"},
+	}
+	for _, test := range tests {
+		if tzid, err := WindowsToIANA(test.WindowsID, test.Territory); err != nil || tzid != test.TZID {
+			t.Errorf("%s %s: got %q %v, expected %q", test.WindowsID, test.Territory, tzid, err, test.TZID)
+		}
+	}
+	if _, err := WindowsToIANA("Martian Standard Time", ""); err == nil {
+		t.Error("expected error for unknown windows time zone")
+	}
+}
+
+func TestWindowsTimeZone(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	if windowsID, err := fc.WindowsTimeZone(40.5, -109.5); err != nil || windowsID != "Mountain Standard Time" {
+		t.Errorf("got %q %v", windowsID, err)
+	}
+	if _, err := fc.WindowsTimeZone(40.5, -107); err == nil {
+		t.Error("expected error outside every zone")
+	}
+}
+
+func TestParseWindowsZones(t *testing.T) {
+	wz, err := parseWindowsZones([]byte("# comment\nA Time\t001\tX/One\nA Time\tZZ\tX/Two X/One\nB Time\tZZ\tX/One\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wz.byTZID["X/One"] != "A Time" || wz.byTZID["X/Two"] != "A Time" || wz.byWindows["A Time"]["ZZ"] != "X/Two" {
+		t.Errorf("got %+v", wz)
+	}
+	if _, err := parseWindowsZones([]byte("A Time\t001\n")); err == nil {
+		t.Error("expected error for missing zones")
+	}
+}
@@ -0,0 +1,153 @@
+package tz
+
+import "sort"
+
+// ZoneInfo describes a time zone in a Collection.
+type ZoneInfo struct {
+	TZID     string
+	Features int
+	Polygons int
+}
+
+// Zones lists every time zone in the collection, sorted by name.
+func (fc Collection) Zones() []ZoneInfo {
+	var byID = make(map[string]*ZoneInfo)
+	for _, f := range fc.Features {
+		if f == nil {
+			continue
+		}
+		tzid := fc.featureTimeZone(f)
+		zone, ok := byID[tzid]
+		if !ok {
+			zone = &ZoneInfo{TZID: tzid}
+			byID[tzid] = zone
+		}
+		zone.Features++
+		zone.Polygons += len(f.Geometry.Coordinates)
+	}
+
+	var zones = make([]ZoneInfo, 0, len(byID))
+	for _, zone := range byID {
+		zones = append(zones, *zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].TZID < zones[j].TZID
+	})
+	return zones
+}
+
+// ZonesInBounds returns the sorted names of the time zones whose polygons intersect the rectangle from
+// minPoint to maxPoint, edges included. Polygons are tested exactly rather than by their bounding boxes.
+// A rectangle whose minimum longitude is greater than its maximum crosses the antimeridian.
+func (fc Collection) ZonesInBounds(minPoint, maxPoint Point) []string {
+	if minPoint.Lat > maxPoint.Lat {
+		return nil
+	}
+	var rects = [][2]Point{{minPoint, maxPoint}}
+	if minPoint.Lon > maxPoint.Lon {
+		rects = [][2]Point{
+			{minPoint, Point{Lon: 180, Lat: maxPoint.Lat}},
+			{Point{Lon: -180, Lat: minPoint.Lat}, maxPoint},
+		}
+	}
+
+	var (
+		seen  = make(map[string]bool)
+		zones []string
+	)
+	for _, f := range fc.Features {
+		if f == nil {
+			continue
+		}
+		tzid := fc.featureTimeZone(f)
+		if seen[tzid] {
+			continue
+		}
+		for _, r := range rects {
+			if f.Geometry.intersectsRect(r[0], r[1]) {
+				seen[tzid] = true
+				zones = append(zones, tzid)
+				break
+			}
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+func (g Geometry) intersectsRect(minPoint, maxPoint Point) bool {
+	if !boundsOverlap(g.MinPoint, g.MaxPoint, minPoint, maxPoint) {
+		return false
+	}
+	for _, c := range g.Coordinates {
+		if c.intersectsRect(minPoint, maxPoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectsRect reports whether the polygon and rectangle share any point: either an edge of the
+// polygon or of one of its holes passes through the rectangle, or the rectangle lies wholly inside the
+// polygon and outside its holes.
+func (c Coordinates) intersectsRect(minPoint, maxPoint Point) bool {
+	if len(c.Polygon) == 0 || !boundsOverlap(c.MinPoint, c.MaxPoint, minPoint, maxPoint) {
+		return false
+	}
+	for _, ring := range append([][]Point{c.Polygon}, c.Holes...) {
+		if ringIntersectsRect(ring, minPoint, maxPoint) {
+			return true
+		}
+	}
+	return c.contains(minPoint, 1)
+}
+
+// ringIntersectsRect reports whether an edge of the ring passes through the rectangle.
+func ringIntersectsRect(ring []Point, minPoint, maxPoint Point) bool {
+	for i := 1; i < len(ring); i++ {
+		if segmentIntersectsRect(ring[i-1], ring[i], minPoint, maxPoint) {
+			return true
+		}
+	}
+	return len(ring) > 0 && segmentIntersectsRect(ring[len(ring)-1], ring[0], minPoint, maxPoint)
+}
+
+func boundsOverlap(minA, maxA, minB, maxB Point) bool {
+	return minA.Lon <= maxB.Lon && maxA.Lon >= minB.Lon && minA.Lat <= maxB.Lat && maxA.Lat >= minB.Lat
+}
+
+// segmentIntersectsRect clips the segment a→b against the rectangle with the Liang–Barsky algorithm
+// and reports whether any of it remains.
+func segmentIntersectsRect(a, b, minPoint, maxPoint Point) bool {
+	var (
+		dx, dy = b.Lon - a.Lon, b.Lat - a.Lat
+		p      = [4]float64{-dx, dx, -dy, dy}
+		q      = [4]float64{a.Lon - minPoint.Lon, maxPoint.Lon - a.Lon, a.Lat - minPoint.Lat, maxPoint.Lat - a.Lat}
+		t0, t1 = 0.0, 1.0
+	)
+	for i := range p {
+		if p[i] == 0 {
+			if q[i] < 0 {
+				return false
+			}
+			continue
+		}
+		r := q[i] / p[i]
+		if p[i] < 0 {
+			if r > t1 {
+				return false
+			}
+			if r > t0 {
+				t0 = r
+			}
+		} else {
+			if r < t0 {
+				return false
+			}
+			if r < t1 {
+				t1 = r
+			}
+		}
+	}
+	return true
+}
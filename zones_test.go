@@ -0,0 +1,67 @@
+package tz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestZones(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var want = []ZoneInfo{
+		{TZID: "America/Denver", Features: 1, Polygons: 1},
+		{TZID: "Pacific/Fiji", Features: 1, Polygons: 2},
+	}
+	if zones := fc.Zones(); !reflect.DeepEqual(zones, want) {
+		t.Errorf("got %+v, expected %+v", zones, want)
+	}
+
+	fc = newTestCollection(t, polarGeoJSON)
+	if zones := fc.Zones(); len(zones) != 8 || zones[0].TZID != "Antarctica/Casey" {
+		t.Errorf("got %+v", zones)
+	}
+}
+
+func TestZonesInBounds(t *testing.T) {
+	var fc = newTestCollection(t, featureGeoJSON)
+	var tests = []struct {
+		Name  string
+		Min   Point
+		Max   Point
+		Zones []string
+	}{
+		{Name: "inside the gap of the U", Min: Point{-108.5, 40}, Max: Point{-105.5, 44}, Zones: nil},
+		{Name: "inside one arm", Min: Point{-109.8, 40}, Max: Point{-109.2, 41}, Zones: []string{"America/Denver"}},
+		{Name: "covering everything", Min: Point{-180, -90}, Max: Point{180, 90}, Zones: []string{"America/Denver", "Pacific/Fiji"}},
+		{Name: "crossing one edge", Min: Point{-106, 44}, Max: Point{-104.5, 46}, Zones: []string{"America/Denver"}},
+		{Name: "touching a corner", Min: Point{-104, 45}, Max: Point{-100, 50}, Zones: []string{"America/Denver"}},
+		{Name: "across the antimeridian", Min: Point{179.5, -16.5}, Max: Point{-179.5, -15}, Zones: []string{"Pacific/Fiji"}},
+		{Name: "east of the antimeridian only", Min: Point{-179.9, -16.9}, Max: Point{-179.1, -16.1}, Zones: []string{"Pacific/Fiji"}},
+		{Name: "between the two fiji polygons", Min: Point{-178, -18}, Max: Point{-170, -17.5}, Zones: nil},
+		{Name: "inverted latitudes", Min: Point{-110, 45}, Max: Point{-104, 35}, Zones: nil},
+	}
+	for _, tt := range tests {
+		if zones := fc.ZonesInBounds(tt.Min, tt.Max); !reflect.DeepEqual(zones, tt.Zones) {
+			t.Errorf("%s: got %v, expected %v", tt.Name, zones, tt.Zones)
+		}
+	}
+}
+
+func TestZonesInBoundsHoles(t *testing.T) {
+	var fc = newTestCollection(t, holedGeoJSON)
+	var tests = []struct {
+		Name  string
+		Min   Point
+		Max   Point
+		Zones []string
+	}{
+		{Name: "inside the enclave", Min: Point{25, -30}, Max: Point{27, -28}, Zones: []string{"Africa/Maseru"}},
+		{Name: "across the edge of the hole", Min: Point{23, -30}, Max: Point{25, -28}, Zones: []string{"Africa/Johannesburg", "Africa/Maseru"}},
+		{Name: "around the enclave", Min: Point{23, -32}, Max: Point{29, -26}, Zones: []string{"Africa/Johannesburg", "Africa/Maseru"}},
+		{Name: "outside the enclave", Min: Point{21, -33}, Max: Point{22, -32}, Zones: []string{"Africa/Johannesburg"}},
+	}
+	for _, test := range tests {
+		if zones := fc.ZonesInBounds(test.Min, test.Max); !reflect.DeepEqual(zones, test.Zones) {
+			t.Errorf("%s: got %v, expected %v", test.Name, zones, test.Zones)
+		}
+	}
+}
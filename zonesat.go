@@ -0,0 +1,81 @@
+package tz
+
+import (
+	"sort"
+	"time"
+)
+
+// ZoneMatch is a time zone selected by ZonesAt.
+type ZoneMatch struct {
+	TZID string
+	// Local is the instant in the time zone.
+	Local time.Time
+	// Offset is the UTC offset in seconds east of UTC at the instant.
+	Offset int
+	// Features hold the polygons of the time zone, a Collection of them marshals to GeoJSON.
+	Features []*Feature
+}
+
+// ZonesAt returns the time zones in the collection whose local time at the instant t satisfies match, sorted
+// by name. LocalTimeBetween and OffsetBetween build common predicates.
+func (fc Collection) ZonesAt(t time.Time, match func(local time.Time) bool) ([]ZoneMatch, error) {
+	var byID = make(map[string]*ZoneMatch)
+	var rejected = make(map[string]bool)
+	for _, f := range fc.Features {
+		if f == nil {
+			continue
+		}
+		tzid := fc.featureTimeZone(f)
+		if rejected[tzid] {
+			continue
+		}
+		if zone, ok := byID[tzid]; ok {
+			zone.Features = append(zone.Features, f)
+			continue
+		}
+		loc, err := LoadLocation(tzid)
+		if err != nil {
+			return nil, err
+		}
+		local := t.In(loc)
+		if !match(local) {
+			rejected[tzid] = true
+			continue
+		}
+		_, offset := local.Zone()
+		byID[tzid] = &ZoneMatch{TZID: tzid, Local: local, Offset: offset, Features: []*Feature{f}}
+	}
+
+	var zones = make([]ZoneMatch, 0, len(byID))
+	for _, zone := range byID {
+		zones = append(zones, *zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].TZID < zones[j].TZID
+	})
+	return zones, nil
+}
+
+// LocalTimeBetween returns a predicate for ZonesAt matching local times of day from start up to but
+// excluding end, both given as durations since midnight. A start after end wraps past midnight, so 22h to
+// 6h matches the night.
+func LocalTimeBetween(start, end time.Duration) func(local time.Time) bool {
+	return func(local time.Time) bool {
+		var hour, min, sec = local.Clock()
+		var d = time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second +
+			time.Duration(local.Nanosecond())
+		if start <= end {
+			return start <= d && d < end
+		}
+		return start <= d || d < end
+	}
+}
+
+// OffsetBetween returns a predicate for ZonesAt matching UTC offsets from low to high seconds east of UTC,
+// both included.
+func OffsetBetween(low, high int) func(local time.Time) bool {
+	return func(local time.Time) bool {
+		_, offset := local.Zone()
+		return low <= offset && offset <= high
+	}
+}
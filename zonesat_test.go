@@ -0,0 +1,56 @@
+package tz
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestZonesAt(t *testing.T) {
+	var fc = newTestCollection(t, equivalenceGeoJSON)
+	// 14:30 UTC in July is 10:30 in New York and Detroit, 7:30 in Phoenix.
+	var at = time.Date(2024, time.July, 1, 14, 30, 0, 0, time.UTC)
+	var tests = []struct {
+		Name  string
+		Match func(time.Time) bool
+		Zones []string
+	}{
+		{Name: "business hours", Match: LocalTimeBetween(9*time.Hour, 17*time.Hour), Zones: []string{"America/Detroit", "America/Indiana/Indianapolis", "America/New_York"}},
+		{Name: "early morning", Match: LocalTimeBetween(7*time.Hour, 8*time.Hour), Zones: []string{"America/Phoenix"}},
+		{Name: "night wrapping midnight", Match: LocalTimeBetween(22*time.Hour, 6*time.Hour), Zones: nil},
+		{Name: "end excluded", Match: LocalTimeBetween(9*time.Hour, 10*time.Hour+30*time.Minute), Zones: nil},
+		{Name: "offset -7h", Match: OffsetBetween(-7*3600, -7*3600), Zones: []string{"America/Phoenix"}},
+		{Name: "offset -5h to -4h", Match: OffsetBetween(-5*3600, -4*3600), Zones: []string{"America/Detroit", "America/Indiana/Indianapolis", "America/New_York"}},
+	}
+	for _, test := range tests {
+		zones, err := fc.ZonesAt(at, test.Match)
+		if err != nil {
+			t.Errorf("%s: %v", test.Name, err)
+			continue
+		}
+		var tzids []string
+		for _, z := range zones {
+			tzids = append(tzids, z.TZID)
+			if len(z.Features) != 1 || z.Features[0].TimeZone() != z.TZID {
+				t.Errorf("%s: %s got features %v", test.Name, z.TZID, z.Features)
+			}
+		}
+		if !reflect.DeepEqual(tzids, test.Zones) {
+			t.Errorf("%s: got %v, expected %v", test.Name, tzids, test.Zones)
+		}
+	}
+
+	zones, _ := fc.ZonesAt(at, OffsetBetween(-7*3600, -7*3600))
+	if len(zones) != 1 || zones[0].Offset != -7*3600 || zones[0].Local.Hour() != 7 || zones[0].Local.Minute() != 30 {
+		t.Errorf("got %+v, expected Phoenix at 7:30 -07:00", zones)
+	}
+}
+
+func TestLocalTimeBetween(t *testing.T) {
+	var match = LocalTimeBetween(22*time.Hour, 6*time.Hour)
+	for hour, want := range map[int]bool{21: false, 22: true, 23: true, 0: true, 5: true, 6: false, 12: false} {
+		if got := match(time.Date(2024, time.March, 1, hour, 0, 0, 0, time.UTC)); got != want {
+			t.Errorf("%d:00: got %v, expected %v", hour, got, want)
+		}
+	}
+}